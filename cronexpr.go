@@ -25,23 +25,31 @@ import (
 
 // A Expression represents a specific cron time expression as defined at
 // <https://github.com/gorhill/cronexpr#implementation>
+//
+// An Expression is not modified once parsed, so it can be shared by several
+// goroutines.
 type Expression struct {
 	expression             string
 	secondList             []int
 	minuteList             []int
 	hourList               []int
-	daysOfMonth            map[int]bool
-	workdaysOfMonth        map[int]bool
+	daysOfMonth            bitset
+	workdaysOfMonth        bitset
 	lastDayOfMonth         bool
 	lastWorkdayOfMonth     bool
 	daysOfMonthRestricted  bool
-	actualDaysOfMonthList  []int
 	monthList              []int
-	daysOfWeek             map[int]bool
-	specificWeekDaysOfWeek map[int]bool
-	lastWeekDaysOfWeek     map[int]bool
+	daysOfWeek             bitset
+	specificWeekDaysOfWeek bitset
+	lastWeekDaysOfWeek     bitset
 	daysOfWeekRestricted   bool
 	yearList               []int
+	seconds                bitset
+	minutes                bitset
+	hours                  bitset
+	months                 bitset
+	satisfiable            bool
+	fields                 [fieldCount]fieldSpan
 }
 
 /******************************************************************************/
@@ -67,11 +75,32 @@ func MustParse(cronLine string) *Expression {
 // about what is a well-formed cron expression from this library's point of
 // view.
 func Parse(cronLine string) (*Expression, error) {
+	return ParseWithOptions(cronLine)
+}
+
+/******************************************************************************/
+
+// ParseWithOptions returns a new Expression pointer, parsed according to the
+// supplied options. An error is returned if a malformed cron expression is
+// supplied.
+func ParseWithOptions(cronLine string, options ...ParseOption) (*Expression, error) {
+	opts := parseOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+	monthDesc, err := monthDescriptor.withNames(opts.monthNames)
+	if err != nil {
+		return nil, err
+	}
+	dowDesc, err := dowDescriptor.withNames(opts.dowNames)
+	if err != nil {
+		return nil, err
+	}
 
 	// Maybe one of the built-in aliases is being used
 	cron := cronNormalizer.Replace(cronLine)
 
-	indices := fieldFinder.FindAllStringIndex(cron, -1)
+	indices := findFields(cron)
 	fieldCount := len(indices)
 	if fieldCount < 5 {
 		return nil, fmt.Errorf("missing field(s)")
@@ -81,9 +110,8 @@ func Parse(cronLine string) (*Expression, error) {
 		fieldCount = 7
 	}
 
-	var expr = Expression{}
+	var expr = Expression{expression: cronLine}
 	var field = 0
-	var err error
 
 	// second field (optional)
 	if fieldCount == 7 {
@@ -118,14 +146,14 @@ func Parse(cronLine string) (*Expression, error) {
 	field += 1
 
 	// month field
-	err = expr.monthFieldHandler(cron[indices[field][0]:indices[field][1]])
+	err = expr.monthFieldHandler(cron[indices[field][0]:indices[field][1]], monthDesc)
 	if err != nil {
 		return nil, err
 	}
 	field += 1
 
 	// day of week field
-	err = expr.dowFieldHandler(cron[indices[field][0]:indices[field][1]])
+	err = expr.dowFieldHandler(cron[indices[field][0]:indices[field][1]], dowDesc)
 	if err != nil {
 		return nil, err
 	}
@@ -141,6 +169,19 @@ func Parse(cronLine string) (*Expression, error) {
 		expr.yearList = yearDescriptor.defaultList
 	}
 
+	expr.seconds = bitsetOf(expr.secondList)
+	expr.minutes = bitsetOf(expr.minuteList)
+	expr.hours = bitsetOf(expr.hourList)
+	expr.months = bitsetOf(expr.monthList)
+	expr.fields = locateFields(cronLine, cron, indices[:fieldCount])
+
+	expr.satisfiable = expr.emptyField() < 0 && expr.matchesSomeDay()
+	if opts.strict {
+		if err = expr.checkSatisfiable(opts.reference); err != nil {
+			return nil, err
+		}
+	}
+
 	return &expr, nil
 }
 
@@ -165,6 +206,9 @@ func (expr *Expression) Next(fromTime time.Time) time.Time {
 	if fromTime.IsZero() {
 		return fromTime
 	}
+	if !expr.satisfiable {
+		return time.Time{}
+	}
 	loc := fromTime.Location()
 	t := roundTimeToNextSec(fromTime)
 
@@ -184,30 +228,30 @@ WRAP:
 	if i := sort.SearchInts(expr.yearList, v); i == len(expr.yearList) {
 		return time.Time{}
 	} else if v != expr.yearList[i] {
-		t = time.Date(expr.yearList[i], time.Month(expr.monthList[0]), 1, 0, 0, 0, 0, loc)
+		t = time.Date(expr.yearList[i], time.Month(expr.months.first()), 1, 0, 0, 0, 0, loc)
 	}
 
 	v = int(t.Month())
-	if i := sort.SearchInts(expr.monthList, v); i == len(expr.monthList) {
+	if month := expr.months.next(v); month < 0 {
 		// try again with a new year
-		t = time.Date(t.Year()+1, time.Month(expr.monthList[0]), 1, 0, 0, 0, 0, loc)
+		t = time.Date(t.Year()+1, time.Month(expr.months.first()), 1, 0, 0, 0, 0, loc)
 		goto WRAP
-	} else if v != expr.monthList[i] {
-		t = time.Date(t.Year(), time.Month(expr.monthList[i]), 1, 0, 0, 0, 0, loc)
+	} else if v != month {
+		t = time.Date(t.Year(), time.Month(month), 1, 0, 0, 0, 0, loc)
 	}
 
-	expr.actualDaysOfMonthList = expr.calculateActualDaysOfMonth(t.Year(), int(t.Month()))
-	if len(expr.actualDaysOfMonthList) == 0 {
+	actualDaysOfMonth := expr.actualDaysOfMonth(t.Year(), int(t.Month()))
+	if actualDaysOfMonth == 0 {
 		t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
 		goto WRAP
 	}
 
 	v = t.Day()
-	if i := sort.SearchInts(expr.actualDaysOfMonthList, v); i == len(expr.actualDaysOfMonthList) {
+	if dom := actualDaysOfMonth.next(v); dom < 0 {
 		t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
 		goto WRAP
-	} else if v != expr.actualDaysOfMonthList[i] {
-		t = time.Date(t.Year(), t.Month(), expr.actualDaysOfMonthList[i], 0, 0, 0, 0, loc)
+	} else if v != dom {
+		t = time.Date(t.Year(), t.Month(), dom, 0, 0, 0, 0, loc)
 
 		// in San Palo, before 2019, there may be no midnight (or multiple midnights)
 		// due to DST
@@ -226,27 +270,27 @@ WRAP:
 
 	// Fast path where hours/minutes behave as expected trivially
 	v = t.Hour()
-	if i := sort.SearchInts(expr.hourList, v); i == len(expr.hourList) {
+	if hour := expr.hours.next(v); hour < 0 {
 		t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
 		goto WRAP
-	} else if v != expr.hourList[i] {
-		t = time.Date(t.Year(), t.Month(), t.Day(), expr.hourList[i], expr.minuteList[0], expr.secondList[0], 0, loc)
+	} else if v != hour {
+		t = time.Date(t.Year(), t.Month(), t.Day(), hour, expr.minutes.first(), expr.seconds.first(), 0, loc)
 	}
 
 	v = t.Minute()
-	if i := sort.SearchInts(expr.minuteList, v); i == len(expr.minuteList) {
+	if minute := expr.minutes.next(v); minute < 0 {
 		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
 		goto WRAP
-	} else if v != expr.minuteList[i] {
-		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), expr.minuteList[i], expr.secondList[0], 0, loc)
+	} else if v != minute {
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), minute, expr.seconds.first(), 0, loc)
 	}
 
 	v = t.Second()
-	if i := sort.SearchInts(expr.secondList, v); i == len(expr.secondList) {
+	if second := expr.seconds.next(v); second < 0 {
 		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
 		goto WRAP
-	} else if v != expr.secondList[i] {
-		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), expr.secondList[i], 0, loc)
+	} else if v != second {
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), second, 0, loc)
 	}
 
 	return t
@@ -255,7 +299,7 @@ SLOW_CLOCK:
 	// daylight saving effect is here, where odd things happen:
 	// An hour may have 60 minutes, 30 minutes or 90 minutes;
 	// partial hours may "repeat"!
-	for !sortContains(expr.hourList, t.Hour()) {
+	for !expr.hours.has(t.Hour()) {
 		hourBefore := t.Hour()
 		t = t.Add(time.Hour)
 		if hourBefore == t.Hour() {
@@ -272,7 +316,7 @@ SLOW_CLOCK:
 		}
 	}
 
-	for !sortContains(expr.minuteList, t.Minute()) {
+	for !expr.minutes.has(t.Minute()) {
 		hoursBefore := t.Hour()
 		t = t.Truncate(time.Minute).Add(time.Minute)
 		if hoursBefore != t.Hour() {
@@ -282,11 +326,11 @@ SLOW_CLOCK:
 
 	v = t.Second()
 	t = t.Truncate(time.Minute)
-	if i := sort.SearchInts(expr.secondList, v); i == len(expr.secondList) {
+	if second := expr.seconds.next(v); second < 0 {
 		t = t.Add(time.Minute)
 		goto WRAP
 	} else {
-		t = t.Add(time.Duration(expr.secondList[i]) * time.Second)
+		t = t.Add(time.Duration(second) * time.Second)
 	}
 
 	return t
@@ -0,0 +1,223 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cal.go
+ * Version: 1.0
+ * License: GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *
+ */
+
+package main
+
+/******************************************************************************/
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/thought-machine/cronexpr"
+)
+
+/******************************************************************************/
+
+var calCommand = &command{
+	name:     "cal",
+	args:     `"{cron expression}"`,
+	summary:  "show the days of a year on which a cron expression fires",
+	complete: completeExpressions,
+	setFlags: setCalFlags,
+	run:      runCal,
+}
+
+var (
+	calYear     int
+	calHeatmap  bool
+	calTimeZone zoneFlag
+)
+
+func setCalFlags(fs *flag.FlagSet) {
+	fs.IntVar(&calYear, "year", time.Now().Year(), `year to show`)
+	fs.Var(&calTimeZone, "tz", `IANA time zone (i.e. "Europe/Paris") in which the cron expression is evaluated, local time zone if not present`)
+	fs.BoolVar(&calHeatmap, "heatmap", false, `show how many times the cron expression fires in each hour of the week over the year, instead of the days on which it fires`)
+}
+
+func runCal(fs *flag.FlagSet) int {
+	expr, err := cronexpr.Parse(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "# %s: %s\n", os.Args[0], err)
+		return 1
+	}
+	if calHeatmap {
+		err = heatmap(os.Stdout, expr, calYear, calTimeZone.location())
+	} else {
+		err = calendar(os.Stdout, expr, calYear, calTimeZone.location(), isTerminal(os.Stdout))
+	}
+	if err != nil {
+		fatalf("%s", err)
+	}
+	return 0
+}
+
+// isTerminal reports whether `f` is a terminal rather than a file or a pipe.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+/******************************************************************************/
+
+const (
+	calMonthWidth = 7 * 3
+	calGutter     = "  "
+	calColumns    = 3
+)
+
+// calendar writes the months of `year` in a grid, as `cal -y` does, where
+// the days on which `expr` fires are highlighted: in reverse video on a
+// terminal, or followed by `*` otherwise.
+func calendar(w io.Writer, expr *cronexpr.Expression, year int, loc *time.Location, terminal bool) error {
+	lines := []string{center(fmt.Sprint(year), calColumns*calMonthWidth+(calColumns-1)*len(calGutter)), ""}
+	for month := time.January; month <= time.December; month += calColumns {
+		rows := make([]string, 8)
+		for i := time.Month(0); i < calColumns; i++ {
+			for j, line := range calendarMonth(expr, year, month+i, loc, terminal) {
+				if i > 0 {
+					rows[j] += calGutter
+				}
+				rows[j] += line
+			}
+		}
+		lines = append(lines, rows...)
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, strings.TrimRight(line, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// calendarMonth returns the lines of the grid of a month: its name, the
+// names of the days of the week, then six weeks, some of which may be blank.
+// All lines are calMonthWidth characters wide, escape sequences aside.
+func calendarMonth(expr *cronexpr.Expression, year int, month time.Month, loc *time.Location, terminal bool) []string {
+	lines := []string{center(month.String(), calMonthWidth), ""}
+	for dow := time.Sunday; dow <= time.Saturday; dow++ {
+		lines[1] += dow.String()[:2] + " "
+	}
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	cell := int(first.Weekday())
+	week := strings.Repeat("   ", cell)
+	for day := first; day.Month() == month; day = day.AddDate(0, 0, 1) {
+		switch {
+		case !firesOn(expr, day):
+			week += fmt.Sprintf("%2d ", day.Day())
+		case terminal:
+			week += fmt.Sprintf("\x1b[7m%2d\x1b[0m ", day.Day())
+		default:
+			week += fmt.Sprintf("%2d*", day.Day())
+		}
+		if cell++; cell%7 == 0 {
+			lines, week = append(lines, week), ""
+		}
+	}
+	if week != "" {
+		lines = append(lines, week+strings.Repeat("   ", 7-cell%7))
+	}
+	for len(lines) < 8 {
+		lines = append(lines, strings.Repeat(" ", calMonthWidth))
+	}
+	return lines
+}
+
+// firesOn reports whether `expr` fires on the day starting at `day`.
+func firesOn(expr *cronexpr.Expression, day time.Time) bool {
+	next := expr.Next(day.Add(-time.Nanosecond))
+	return !next.IsZero() && next.Before(day.AddDate(0, 0, 1))
+}
+
+func center(s string, width int) string {
+	pad := (width - len(s)) / 2
+	if pad < 0 {
+		pad = 0
+	}
+	s = strings.Repeat(" ", pad) + s
+	return s + strings.Repeat(" ", width-len(s))
+}
+
+/******************************************************************************/
+
+// heatShades are the shades of the cells of the heatmap, from no fire to the
+// most fires.
+var heatShades = []string{"·", "░", "▒", "▓", "█"}
+
+// heatmap writes how many times `expr` fires in each hour of each day of the
+// week over `year`, as a grid of shades with a legend of the counts each
+// shade stands for.
+func heatmap(w io.Writer, expr *cronexpr.Expression, year int, loc *time.Location) error {
+	var counts [7][24]int
+	begin := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	end := begin.AddDate(1, 0, 0)
+	total, max := 0, 0
+	for t := expr.Next(begin.Add(-time.Nanosecond)); !t.IsZero() && t.Before(end); t = expr.Next(t) {
+		count := &counts[t.Weekday()][t.Hour()]
+		if *count++; *count > max {
+			max = *count
+		}
+		total++
+	}
+
+	// Each shade but the first covers a quarter of the counts, up to the
+	// largest count
+	shade := func(count int) int {
+		if count == 0 {
+			return 0
+		}
+		return 1 + (count-1)*(len(heatShades)-1)/max
+	}
+	var lows, highs [5]int
+	for _, day := range counts {
+		for _, count := range day {
+			i := shade(count)
+			if lows[i] == 0 || count < lows[i] {
+				lows[i] = count
+			}
+			if count > highs[i] {
+				highs[i] = count
+			}
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %d fires in %d\n", total, year)
+	hours := "   "
+	for hour := 0; hour < 24; hour += 2 {
+		hours += fmt.Sprintf("%-4d", hour)
+	}
+	b.WriteString(strings.TrimRight(hours, " ") + "\n")
+	for dow := time.Sunday; dow <= time.Saturday; dow++ {
+		b.WriteString(dow.String()[:2] + " ")
+		for _, count := range counts[dow] {
+			b.WriteString(strings.Repeat(heatShades[shade(count)], 2))
+		}
+		b.WriteString("\n")
+	}
+	legend := []string{heatShades[0] + " 0"}
+	for i := 1; i < len(heatShades); i++ {
+		switch {
+		case highs[i] == 0:
+		case lows[i] == highs[i]:
+			legend = append(legend, fmt.Sprintf("%s %d", heatShades[i], lows[i]))
+		default:
+			legend = append(legend, fmt.Sprintf("%s %d-%d", heatShades[i], lows[i], highs[i]))
+		}
+	}
+	fmt.Fprintf(&b, "%s\n", strings.Join(legend, "  "))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,53 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cal_test.go
+ * Version: 1.0
+ * License: GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *
+ */
+
+package main
+
+/******************************************************************************/
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/thought-machine/cronexpr"
+)
+
+/******************************************************************************/
+
+func TestCalendarMonth(t *testing.T) {
+	expr := cronexpr.MustParse("0 0 * * 6#5")
+	require.Equal(t, []string{
+		"       January       ",
+		"Su Mo Tu We Th Fr Sa ",
+		"                1  2 ",
+		" 3  4  5  6  7  8  9 ",
+		"10 11 12 13 14 15 16 ",
+		"17 18 19 20 21 22 23 ",
+		"24 25 26 27 28 29 30*",
+		"31                   ",
+	}, calendarMonth(expr, 2027, time.January, time.UTC, false))
+	require.Equal(t, "28                   ", calendarMonth(expr, 2027, time.February, time.UTC, false)[6])
+	require.Equal(t, strings.Repeat(" ", calMonthWidth), calendarMonth(expr, 2027, time.February, time.UTC, false)[7])
+	require.Equal(t, "24 25 26 27 28 29 \x1b[7m30\x1b[0m ", calendarMonth(expr, 2027, time.January, time.UTC, true)[6])
+}
+
+func TestHeatmap(t *testing.T) {
+	var b strings.Builder
+	require.NoError(t, heatmap(&b, cronexpr.MustParse("0 0,30 9 * * 1-5 *"), 2027, time.UTC))
+	lines := strings.Split(b.String(), "\n")
+	require.Equal(t, "# 522 fires in 2027", lines[0])
+	require.Equal(t, "   0   2   4   6   8   10  12  14  16  18  20  22", lines[1])
+	require.Equal(t, "Su "+strings.Repeat("··", 24), lines[2])
+	require.Equal(t, "Mo "+strings.Repeat("··", 9)+"██"+strings.Repeat("··", 14), lines[3])
+	require.Equal(t, "· 0  █ 104-106", lines[9])
+}
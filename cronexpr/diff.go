@@ -0,0 +1,127 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: diff.go
+ * Version: 1.0
+ * License: GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *
+ */
+
+package main
+
+/******************************************************************************/
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/thought-machine/cronexpr"
+)
+
+/******************************************************************************/
+
+var diffCommand = &command{
+	name:     "diff",
+	args:     `"{old cron expression}" "{new cron expression}"`,
+	summary:  "compare the time values matching two cron expressions",
+	complete: completeExpressions,
+	setFlags: setDiffFlags,
+	run:      runDiff,
+}
+
+var (
+	diffTimeStr    string
+	diffDays       uint
+	diffAll        bool
+	diffTimeLayout string
+	diffTimeZone   zoneFlag
+)
+
+func setDiffFlags(fs *flag.FlagSet) {
+	fs.StringVar(&diffTimeStr, "t", "", `whole or partial RFC3339 time value from which the cron expressions are compared, now if not present`)
+	fs.UintVar(&diffDays, "days", 30, `number of days over which the cron expressions are compared`)
+	fs.Var(&diffTimeZone, "tz", `IANA time zone (i.e. "Europe/Paris") in which the cron expressions are evaluated, local time zone if not present`)
+	fs.BoolVar(&diffAll, "all", false, `also output the time values matching both cron expressions`)
+	fs.StringVar(&diffTimeLayout, "l", "Mon, 02 Jan 2006 15:04:05 MST", `Go-compliant time layout to use for outputting time values, see <http://golang.org/pkg/time/#pkg-constants>`)
+}
+
+// runDiff exits as diff(1) does: with 0 if the cron expressions are
+// equivalent, 1 if they are not, and 2 on errors.
+func runDiff(fs *flag.FlagSet) int {
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return 2
+	}
+	oldStr, newStr := fs.Arg(0), fs.Arg(1)
+	fromTime, err := parseTime(diffTimeStr, time.Now(), diffTimeZone.location())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "# error: %s\n", err)
+		return 2
+	}
+	if diffTimeZone.loc != nil {
+		fromTime = fromTime.In(diffTimeZone.loc)
+	}
+	oldExpr, err := cronexpr.Parse(oldStr)
+	if err == nil {
+		var newExpr *cronexpr.Expression
+		if newExpr, err = cronexpr.Parse(newStr); err == nil {
+			toTime := fromTime.AddDate(0, 0, int(diffDays))
+			fmt.Printf("--- \"%s\"\n+++ \"%s\"\n", oldStr, newStr)
+			var changes int
+			changes, err = diff(os.Stdout, oldExpr, newExpr, fromTime, toTime, diffTimeLayout, diffAll)
+			if err == nil && !cronexpr.Equal(oldExpr, newExpr) {
+				if changes == 0 {
+					fmt.Printf("# no difference over %d days, but the cron expressions are not equivalent\n", diffDays)
+				}
+				return 1
+			}
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "# %s: %s\n", os.Args[0], err)
+		return 2
+	}
+	return 0
+}
+
+/******************************************************************************/
+
+// diff writes, in chronological order, the time values after `fromTime`
+// and up to `toTime`, included, which match `oldExpr` only, prefixed with
+// `-`, and those which match `newExpr` only, prefixed with `+`, formatted
+// according to `layout`. Time values matching both are prefixed with a space,
+// and written only if `all` is set. It returns how many time values match
+// only one of the cron expressions.
+func diff(w io.Writer, oldExpr, newExpr *cronexpr.Expression, fromTime, toTime time.Time, layout string, all bool) (int, error) {
+	within := func(t time.Time) bool {
+		return !t.IsZero() && !t.After(toTime)
+	}
+	changes := 0
+	oldTime, newTime := oldExpr.Next(fromTime), newExpr.Next(fromTime)
+	for within(oldTime) || within(newTime) {
+		var err error
+		switch {
+		case !within(newTime) || within(oldTime) && oldTime.Before(newTime):
+			_, err = fmt.Fprintf(w, "-%s\n", oldTime.Format(layout))
+			oldTime = oldExpr.Next(oldTime)
+			changes++
+		case !within(oldTime) || newTime.Before(oldTime):
+			_, err = fmt.Fprintf(w, "+%s\n", newTime.Format(layout))
+			newTime = newExpr.Next(newTime)
+			changes++
+		default:
+			if all {
+				_, err = fmt.Fprintf(w, " %s\n", oldTime.Format(layout))
+			}
+			oldTime, newTime = oldExpr.Next(oldTime), newExpr.Next(newTime)
+		}
+		if err != nil {
+			return changes, err
+		}
+	}
+	return changes, nil
+}
@@ -0,0 +1,59 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: diff_test.go
+ * Version: 1.0
+ * License: GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *
+ */
+
+package main
+
+/******************************************************************************/
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/thought-machine/cronexpr"
+)
+
+/******************************************************************************/
+
+func TestDiff(t *testing.T) {
+	fromTime := time.Date(2027, time.March, 1, 0, 0, 0, 0, time.UTC)
+	toTime := fromTime.AddDate(0, 0, 7)
+	oldExpr, newExpr := cronexpr.MustParse("0 9 * * 1-5"), cronexpr.MustParse("0 9,12 * * 1-4,6")
+
+	var b strings.Builder
+	changes, err := diff(&b, oldExpr, newExpr, fromTime, toTime, time.RFC3339, false)
+	require.NoError(t, err)
+	require.Equal(t, 7, changes)
+	require.Equal(t, strings.Join([]string{
+		"+2027-03-01T12:00:00Z",
+		"+2027-03-02T12:00:00Z",
+		"+2027-03-03T12:00:00Z",
+		"+2027-03-04T12:00:00Z",
+		"-2027-03-05T09:00:00Z",
+		"+2027-03-06T09:00:00Z",
+		"+2027-03-06T12:00:00Z",
+		"",
+	}, "\n"), b.String())
+
+	b.Reset()
+	changes, err = diff(&b, oldExpr, newExpr, fromTime, fromTime.AddDate(0, 0, 1), time.RFC3339, true)
+	require.NoError(t, err)
+	require.Equal(t, 1, changes)
+	require.Equal(t, " 2027-03-01T09:00:00Z\n+2027-03-01T12:00:00Z\n", b.String())
+
+	// Time values end with the last matching year
+	b.Reset()
+	changes, err = diff(&b, cronexpr.MustParse("0 0 1 1 * 2027"), cronexpr.MustParse("0 0 1 1 * 2028"), fromTime, fromTime.AddDate(5, 0, 0), time.RFC3339, true)
+	require.NoError(t, err)
+	require.Equal(t, 1, changes)
+	require.Equal(t, "+2028-01-01T00:00:00Z\n", b.String())
+}
@@ -0,0 +1,113 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: explain.go
+ * Version: 1.0
+ * License: GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *
+ */
+
+package main
+
+/******************************************************************************/
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/thought-machine/cronexpr"
+)
+
+/******************************************************************************/
+
+var explainCommand = &command{
+	name:     "explain",
+	args:     `"{cron expression}"`,
+	summary:  "break a cron expression down field by field",
+	complete: completeExpressions,
+	setFlags: func(fs *flag.FlagSet) {},
+	run:      runExplain,
+}
+
+func runExplain(fs *flag.FlagSet) int {
+	expr, err := cronexpr.Parse(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "# %s: %s\n", os.Args[0], err)
+		return 1
+	}
+	if err := explain(os.Stdout, fs.Arg(0), expr); err != nil {
+		fatalf("%s", err)
+	}
+	return 0
+}
+
+// explain writes one row per field of `expr`, with its token and the values
+// it expands to, followed by how days are matched and the description of
+// `expr`.
+func explain(w io.Writer, cronStr string, expr *cronexpr.Expression) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "# \"%s\"\n", cronStr)
+	fmt.Fprintln(tw, "field\ttoken\tvalues")
+	fields := expr.Fields()
+	for _, field := range fields {
+		token := field.Text
+		if token == "" {
+			token = "(absent)"
+		}
+		values := append([]string{}, field.Special...)
+		if len(field.Values) > 0 || len(values) == 0 {
+			values = append([]string{valueRanges(field.Values)}, values...)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", field.Name, token, strings.Join(values, ","))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "days: %s\ndescription: %s\n", daysSemantics(fields[3], fields[5]), cronexpr.Describe(expr))
+	return err
+}
+
+// daysSemantics explains how the day-of-month and day-of-week fields
+// combine: a day matches when either restricted field matches it.
+func daysSemantics(dom, dow cronexpr.Field) string {
+	switch {
+	case dom.Restricted && dow.Restricted:
+		return fmt.Sprintf("%s and %s are both restricted, a day matches if either of them matches (OR)", dom.Name, dow.Name)
+	case dom.Restricted:
+		return fmt.Sprintf("%s is unrestricted, only %s selects days", dow.Name, dom.Name)
+	case dow.Restricted:
+		return fmt.Sprintf("%s is unrestricted, only %s selects days", dom.Name, dow.Name)
+	}
+	return fmt.Sprintf("%s and %s are both unrestricted, every day matches", dom.Name, dow.Name)
+}
+
+// valueRanges formats ascending values as a list of values and ranges of
+// consecutive values, i.e. "0-5,10,20-22", or "none" for no value.
+func valueRanges(values []int) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	ranges := []string{}
+	for i := 0; i < len(values); {
+		j := i + 1
+		for j < len(values) && values[j] == values[j-1]+1 {
+			j++
+		}
+		switch j - i {
+		case 1:
+			ranges = append(ranges, strconv.Itoa(values[i]))
+		case 2:
+			ranges = append(ranges, strconv.Itoa(values[i]), strconv.Itoa(values[i+1]))
+		default:
+			ranges = append(ranges, fmt.Sprintf("%d-%d", values[i], values[j-1]))
+		}
+		i = j
+	}
+	return strings.Join(ranges, ",")
+}
@@ -0,0 +1,480 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: gen.go
+ * Version: 1.0
+ * License: GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *
+ */
+
+package main
+
+/******************************************************************************/
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/thought-machine/cronexpr"
+)
+
+/******************************************************************************/
+
+var genCompletionCommand = &command{
+	name:     "gen-completion",
+	args:     "{bash|zsh|fish}",
+	summary:  "output the completion script of a shell",
+	complete: completeShells,
+	setFlags: func(fs *flag.FlagSet) {},
+	run:      runGenCompletion,
+}
+
+var genManCommand = &command{
+	name:     "gen-man",
+	summary:  "output the manual page, in roff",
+	setFlags: func(fs *flag.FlagSet) {},
+	run:      runGenMan,
+}
+
+var completionTemplates = map[string]*template.Template{
+	"bash": newTemplate("bash", bashCompletion),
+	"zsh":  newTemplate("zsh", zshCompletion),
+	"fish": newTemplate("fish", fishCompletion),
+}
+
+func runGenCompletion(fs *flag.FlagSet) int {
+	tmpl, ok := completionTemplates[fs.Arg(0)]
+	if !ok {
+		fatalf("unknown shell: \"%s\", one of bash, zsh or fish", fs.Arg(0))
+	}
+	if err := tmpl.Execute(os.Stdout, newCompletionData(zoneNames())); err != nil {
+		fatalf("%s", err)
+	}
+	return 0
+}
+
+func runGenMan(fs *flag.FlagSet) int {
+	if err := genMan(os.Stdout); err != nil {
+		fatalf("%s", err)
+	}
+	return 0
+}
+
+/******************************************************************************/
+
+// completionData is what completion scripts are made of.
+type completionData struct {
+	Default      string
+	Commands     []completionCommand
+	CommandNames []string
+	// Names of the commands whose arguments are cron expressions, files or
+	// shells
+	ExpressionCommands, FileCommands, ShellCommands []string
+	// Names of the options whose values are time zones, output formats, or
+	// anything else
+	ZoneFlags, FormatFlags, ValueFlags []string
+	Zones                              []string
+	// Predefined expressions, then names of months and days of the week
+	Names   []string
+	Formats []string
+	Shells  []string
+}
+
+type completionCommand struct {
+	Name, Summary string
+	Flags         []completionFlag
+}
+
+type completionFlag struct {
+	Name, Usage string
+	// Whether the option takes a value, and what it is if known: "zones"
+	// or "formats"
+	Value  bool
+	Values string
+}
+
+func newCompletionData(zones []string) completionData {
+	data := completionData{
+		Default: commands[0].name,
+		Zones:   zones,
+		Names:   append(append(cronexpr.PredefinedExpressions(), cronexpr.MonthNames()...), cronexpr.DayOfWeekNames()...),
+		Formats: outputFormats,
+		Shells:  []string{"bash", "zsh", "fish"},
+	}
+	seen := map[string]bool{}
+	for _, cmd := range commands {
+		completion := completionCommand{Name: cmd.name, Summary: cmd.summary}
+		data.CommandNames = append(data.CommandNames, cmd.name)
+		switch cmd.complete {
+		case completeExpressions:
+			data.ExpressionCommands = append(data.ExpressionCommands, cmd.name)
+		case completeFiles:
+			data.FileCommands = append(data.FileCommands, cmd.name)
+		case completeShells:
+			data.ShellCommands = append(data.ShellCommands, cmd.name)
+		}
+		visitFlags(cmd, func(f *flag.Flag) {
+			completion.Flags = append(completion.Flags, newCompletionFlag(f))
+			if seen[f.Name] {
+				return
+			}
+			seen[f.Name] = true
+			switch flag := completion.Flags[len(completion.Flags)-1]; {
+			case flag.Values == "zones":
+				data.ZoneFlags = append(data.ZoneFlags, "-"+f.Name)
+			case flag.Values == "formats":
+				data.FormatFlags = append(data.FormatFlags, "-"+f.Name)
+			case flag.Value:
+				data.ValueFlags = append(data.ValueFlags, "-"+f.Name)
+			}
+		})
+		data.Commands = append(data.Commands, completion)
+	}
+	return data
+}
+
+func newCompletionFlag(f *flag.Flag) completionFlag {
+	completion := completionFlag{Name: f.Name, Usage: f.Usage, Value: true}
+	switch value := f.Value.(type) {
+	case *zoneFlag, *zoneListFlag:
+		completion.Values = "zones"
+	case interface{ IsBoolFlag() bool }:
+		completion.Value = !value.IsBoolFlag()
+	}
+	if f.Name == "o" {
+		completion.Values = "formats"
+	}
+	return completion
+}
+
+// visitFlags calls `visit` for each option of `cmd`, in lexicographical
+// order.
+func visitFlags(cmd *command, visit func(f *flag.Flag)) {
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	cmd.setFlags(fs)
+	fs.VisitAll(visit)
+}
+
+// zoneNames returns the names of the IANA time zones found in the time zone
+// database of the system, or none if there is no such database.
+func zoneNames() []string {
+	dirs := []string{os.Getenv("ZONEINFO"), "/usr/share/zoneinfo", "/usr/share/lib/zoneinfo", "/usr/lib/locale/TZ"}
+	for _, dir := range dirs {
+		if info, err := os.Stat(dir); dir == "" || err != nil || !info.IsDir() {
+			continue
+		}
+		names := []string{}
+		filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			name, _ := filepath.Rel(dir, path)
+			switch {
+			case d.IsDir() && (name == "posix" || name == "right"):
+				return filepath.SkipDir
+			case d.IsDir() || !unicode.IsUpper(rune(name[0])) || strings.ContainsAny(name, "."):
+				return nil
+			}
+			if _, err := time.LoadLocation(name); err == nil {
+				names = append(names, name)
+			}
+			return nil
+		})
+		sort.Strings(names)
+		return names
+	}
+	return []string{}
+}
+
+/******************************************************************************/
+
+var templateFuncs = template.FuncMap{
+	"join": strings.Join,
+	"fishQuote": func(s string) string {
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+	},
+	"flagNames": func(flags []completionFlag) string {
+		names := []string{}
+		for _, f := range flags {
+			names = append(names, "-"+f.Name)
+		}
+		return strings.Join(names, " ")
+	},
+}
+
+func newTemplate(name, text string) *template.Template {
+	return template.Must(template.New(name).Funcs(templateFuncs).Parse(text))
+}
+
+const bashCompletion = `# bash completion for cronexpr, generated by ` + "`cronexpr gen-completion bash`" + `
+
+_cronexpr_zones='{{join .Zones " "}}'
+_cronexpr_names='{{join .Names " "}}'
+
+_cronexpr() {
+    local cur=${COMP_WORDS[COMP_CWORD]} prev=${COMP_WORDS[COMP_CWORD-1]}
+    local cmd={{.Default}} words
+    if (( COMP_CWORD > 1 )); then
+        case ${COMP_WORDS[1]} in
+        {{join .CommandNames "|"}}) cmd=${COMP_WORDS[1]} ;;
+        esac
+    fi
+
+    case $prev in
+{{- if .ZoneFlags}}
+    {{join .ZoneFlags "|"}})
+        COMPREPLY=($(compgen -W "$_cronexpr_zones" -- "$cur"))
+        return ;;
+{{- end}}
+{{- if .FormatFlags}}
+    {{join .FormatFlags "|"}})
+        COMPREPLY=($(compgen -W '{{join .Formats " "}}' -- "$cur"))
+        return ;;
+{{- end}}
+{{- if .ValueFlags}}
+    {{join .ValueFlags "|"}})
+        return ;;
+{{- end}}
+    esac
+
+    if [[ $cur == -* ]]; then
+        case $cmd in
+{{- range .Commands}}{{if .Flags}}
+        {{.Name}}) words='{{flagNames .Flags}}' ;;
+{{- end}}{{end}}
+        esac
+        COMPREPLY=($(compgen -W "$words" -- "$cur"))
+        return
+    fi
+
+    if (( COMP_CWORD == 1 )); then
+        COMPREPLY=($(compgen -W '{{join .CommandNames " "}}' -- "$cur"))
+    fi
+    case $cmd in
+    {{join .ExpressionCommands "|"}})
+        # Complete the last word of the cron expression
+        local last=${cur##*[ \"\']}
+        local IFS=$'\n'
+        COMPREPLY+=($(compgen -P "${cur%"$last"}" -W "${_cronexpr_names// /$'\n'}" -- "$last")) ;;
+    {{join .FileCommands "|"}})
+        COMPREPLY+=($(compgen -f -- "$cur")) ;;
+    {{join .ShellCommands "|"}})
+        COMPREPLY+=($(compgen -W '{{join .Shells " "}}' -- "$cur")) ;;
+    esac
+}
+
+complete -F _cronexpr cronexpr
+`
+
+const zshCompletion = `#compdef cronexpr
+# zsh completion for cronexpr, generated by ` + "`cronexpr gen-completion zsh`" + `
+
+_cronexpr() {
+    local -a zones names commands flags
+    zones=({{join .Zones " "}})
+    names=({{join .Names " "}})
+    commands=({{join .CommandNames " "}})
+    local cmd={{.Default}}
+    if (( CURRENT > 2 && ${commands[(Ie)$words[2]]} )); then
+        cmd=$words[2]
+    fi
+
+    case $words[CURRENT-1] in
+{{- if .ZoneFlags}}
+    {{join .ZoneFlags "|"}})
+        compadd -a zones
+        return ;;
+{{- end}}
+{{- if .FormatFlags}}
+    {{join .FormatFlags "|"}})
+        compadd {{join .Formats " "}}
+        return ;;
+{{- end}}
+{{- if .ValueFlags}}
+    {{join .ValueFlags "|"}})
+        return ;;
+{{- end}}
+    esac
+
+    if [[ $PREFIX == -* ]]; then
+        case $cmd in
+{{- range .Commands}}{{if .Flags}}
+        {{.Name}}) flags=({{flagNames .Flags}}) ;;
+{{- end}}{{end}}
+        esac
+        compadd -a flags
+        return
+    fi
+
+    if (( CURRENT == 2 )); then
+        compadd -a commands
+    fi
+    case $cmd in
+    {{join .ExpressionCommands "|"}})
+        # Complete the last word of the cron expression
+        compset -P '*[ "'\'']'
+        compadd -a names ;;
+    {{join .FileCommands "|"}})
+        _files ;;
+    {{join .ShellCommands "|"}})
+        compadd {{join .Shells " "}} ;;
+    esac
+}
+
+_cronexpr "$@"
+`
+
+const fishCompletion = `# fish completion for cronexpr, generated by ` + "`cronexpr gen-completion fish`" + `
+
+function __cronexpr_zones
+    printf '%s\n' {{join .Zones " "}}
+end
+
+# Completes the last word of the cron expression
+function __cronexpr_names
+    set -l prefix (string replace -r '[^ ]*$' '' -- (commandline -ct))
+    printf "$prefix%s\n" {{join .Names " "}}
+end
+
+# Whether the command line is for one of the commands given as arguments,
+# the default command being implied when no command is named
+function __cronexpr_using
+    set -l words (commandline -opc)
+    if set -q words[2]; and contains -- $words[2] {{join .CommandNames " "}}
+        contains -- $words[2] $argv
+    else
+        contains -- {{.Default}} $argv
+    end
+end
+
+complete -c cronexpr -f
+{{- range .Commands}}
+complete -c cronexpr -n 'not __fish_seen_subcommand_from {{join $.CommandNames " "}}' -a {{.Name}} -d {{fishQuote .Summary}}
+{{- end}}
+{{- range .Commands}}
+{{- $name := .Name}}
+{{- range .Flags}}
+complete -c cronexpr -n '__cronexpr_using {{$name}}' -o {{.Name}}
+{{- if eq .Values "zones"}} -x -a '(__cronexpr_zones)'
+{{- else if eq .Values "formats"}} -x -a '{{join $.Formats " "}}'
+{{- else if .Value}} -x
+{{- end}} -d {{fishQuote .Usage}}
+{{- end}}
+{{- end}}
+complete -c cronexpr -n '__cronexpr_using {{join .ExpressionCommands " "}}' -a '(__cronexpr_names)'
+complete -c cronexpr -n '__cronexpr_using {{join .FileCommands " "}}' -F
+complete -c cronexpr -n '__cronexpr_using {{join .ShellCommands " "}}' -a '{{join .Shells " "}}'
+`
+
+/******************************************************************************/
+
+// genMan writes the manual page of the utility, in roff.
+func genMan(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString(`.TH CRONEXPR 1 "" "cronexpr" "User Commands"
+.SH NAME
+cronexpr \- evaluate cron time expressions
+.SH SYNOPSIS
+.B cronexpr
+[\fIcommand\fR] [\fIoptions\fR] \fIargs\fR
+.SH DESCRIPTION
+.B cronexpr
+outputs the time values matching cron expressions, explains them, checks the
+schedules of crontab files and Kubernetes manifests, and compares them.
+Without a command,
+.B cronexpr
+runs the
+.B next
+command.
+.SH COMMANDS
+`)
+	for _, cmd := range commands {
+		fmt.Fprintf(&b, ".SS %s\n.B cronexpr %s\n", roffEscape(cmd.name), roffEscape(cmd.name))
+		hasFlags := false
+		visitFlags(cmd, func(*flag.Flag) { hasFlags = true })
+		if hasFlags {
+			b.WriteString("[\\fIoptions\\fR]\n")
+		}
+		if cmd.args != "" {
+			fmt.Fprintf(&b, "\\fI%s\\fR\n", roffEscape(cmd.args))
+		}
+		fmt.Fprintf(&b, ".PP\n%s.\n", roffEscape(upperFirst(cmd.summary)))
+		visitFlags(cmd, func(f *flag.Flag) {
+			name, usage := flag.UnquoteUsage(f)
+			completion := newCompletionFlag(f)
+			if completion.Values == "zones" {
+				name = "zone"
+			}
+			if completion.Value {
+				fmt.Fprintf(&b, ".TP\n.BI \\-%s \" %s\"\n", roffEscape(f.Name), roffEscape(name))
+			} else {
+				fmt.Fprintf(&b, ".TP\n.B \\-%s\n", roffEscape(f.Name))
+			}
+			b.WriteString(roffEscape(upperFirst(usage)))
+			switch {
+			case name == "string" && f.DefValue != "":
+				fmt.Fprintf(&b, " (default \"%s\")", roffEscape(f.DefValue))
+			case completion.Value && f.DefValue != "" && f.DefValue != "0":
+				fmt.Fprintf(&b, " (default %s)", roffEscape(f.DefValue))
+			}
+			b.WriteString(".\n")
+		})
+	}
+	fmt.Fprintf(&b, `.SH CRON EXPRESSIONS
+A cron expression is made of five to seven fields separated by spaces:
+an optional second field, then the minute, hour, day\-of\-month, month and
+day\-of\-week fields, then an optional year field.
+Each field is \fB*\fR, or a list of values, ranges and steps, such as
+\fB0,30\fR, \fB9\-17\fR or \fB*/15\fR.
+The day\-of\-month field also accepts \fBL\fR, \fBLW\fR and \fB15W\fR, and the
+day\-of\-week field \fB5L\fR and \fB5#3\fR.
+.PP
+Predefined expressions: %s.
+.PP
+Names of months: %s.
+.PP
+Names of days of the week: %s.
+.SH EXIT STATUS
+0 on success, 1 on errors, such as invalid cron expressions or, for
+.BR lint ,
+errors in files.
+.B diff
+exits with 0 if the cron expressions are equivalent, 1 if they are not, and 2
+on errors.
+.SH SEE ALSO
+.BR crontab (5),
+.BR cron (8)
+`, roffEscape(strings.Join(cronexpr.PredefinedExpressions(), ", ")),
+		roffEscape(strings.Join(cronexpr.MonthNames(), ", ")),
+		roffEscape(strings.Join(cronexpr.DayOfWeekNames(), ", ")))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var roffEscaper = strings.NewReplacer(`\`, `\e`, `-`, `\-`)
+
+// roffEscape escapes `s` for use in a line of text of a roff document.
+func roffEscape(s string) string {
+	s = roffEscaper.Replace(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
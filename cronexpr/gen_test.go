@@ -0,0 +1,67 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: gen_test.go
+ * Version: 1.0
+ * License: GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *
+ */
+
+package main
+
+/******************************************************************************/
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+/******************************************************************************/
+
+func TestCompletionData(t *testing.T) {
+	data := newCompletionData([]string{"Europe/Paris", "UTC"})
+	require.Equal(t, "next", data.Default)
+	require.Len(t, data.Commands, len(commands))
+	require.Equal(t, []string{"next", "explain", "cal", "diff", "to-systemd"}, data.ExpressionCommands)
+	require.Equal(t, []string{"lint"}, data.FileCommands)
+	require.Equal(t, []string{"gen-completion"}, data.ShellCommands)
+	require.Equal(t, []string{"-show-tz", "-tz"}, data.ZoneFlags)
+	require.Equal(t, []string{"-o"}, data.FormatFlags)
+	require.Contains(t, data.ValueFlags, "-t")
+	require.NotContains(t, data.ValueFlags, "-heatmap")
+	require.Equal(t, "@yearly", data.Names[0])
+	require.Contains(t, data.Names, "september")
+	require.Contains(t, data.Names, "fri")
+
+	require.Equal(t, completionFlag{Name: "tz", Usage: data.Commands[0].Flags[8].Usage, Value: true, Values: "zones"}, data.Commands[0].Flags[8])
+	require.Equal(t, completionFlag{Name: "system", Usage: data.Commands[2].Flags[1].Usage}, data.Commands[2].Flags[1])
+}
+
+func TestCompletionScripts(t *testing.T) {
+	data := newCompletionData([]string{"Europe/Paris", "UTC"})
+	for shell, tmpl := range completionTemplates {
+		var b strings.Builder
+		require.NoError(t, tmpl.Execute(&b, data), shell)
+		require.Contains(t, b.String(), "Europe/Paris UTC", shell)
+		require.Contains(t, b.String(), "@hourly", shell)
+		require.Contains(t, b.String(), "wednesday", shell)
+		require.Contains(t, b.String(), "gen-completion", shell)
+	}
+}
+
+func TestGenMan(t *testing.T) {
+	var b strings.Builder
+	require.NoError(t, genMan(&b))
+	man := b.String()
+	require.True(t, strings.HasPrefix(man, ".TH CRONEXPR 1 "))
+	for _, cmd := range commands {
+		require.Contains(t, man, ".SS "+roffEscape(cmd.name)+"\n")
+	}
+	require.Contains(t, man, ".BI \\-show\\-tz \" zone\"\n")
+	require.Contains(t, man, ".B \\-heatmap\n")
+	require.Contains(t, man, "(default \"Mon, 02 Jan 2006 15:04:05 MST\")")
+	require.Equal(t, `\&.hidden \e \-`, roffEscape(`.hidden \ -`))
+}
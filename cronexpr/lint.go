@@ -0,0 +1,268 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: lint.go
+ * Version: 1.0
+ * License: GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *
+ */
+
+package main
+
+/******************************************************************************/
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/thought-machine/cronexpr"
+)
+
+/******************************************************************************/
+
+var lintCommand = &command{
+	name:     "lint",
+	args:     "FILE...",
+	summary:  "check the schedules of crontab files and Kubernetes manifests",
+	complete: completeFiles,
+	setFlags: setLintFlags,
+	run:      runLint,
+}
+
+var (
+	lintSystem   bool
+	lintManifest bool
+)
+
+func setLintFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&lintSystem, "system", false, `read all files as system crontabs, with a user column, as /etc/crontab and the files of /etc/cron.d are`)
+	fs.BoolVar(&lintManifest, "manifest", false, `read all files as YAML manifests, whose "schedule" keys hold cron expressions, as .yaml and .yml files are`)
+}
+
+/******************************************************************************/
+
+// Severities of diagnostics
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// A diagnostic reports an error or a warning at a 1-based line and column of
+// a file.
+type diagnostic struct {
+	file      string
+	line, col int
+	severity  string
+	message   string
+}
+
+func (d diagnostic) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s", d.file, d.line, d.col, d.severity, d.message)
+}
+
+// runLint reports the diagnostics of all the files, and fails if any of them
+// is an error.
+func runLint(fs *flag.FlagSet) int {
+	status := 0
+	for _, file := range fs.Args() {
+		diagnostics, err := lintFile(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "# error: %s\n", err)
+			status = 1
+		}
+		for _, d := range diagnostics {
+			fmt.Println(d)
+			if d.severity == severityError {
+				status = 1
+			}
+		}
+	}
+	return status
+}
+
+func lintFile(file string) ([]diagnostic, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch {
+	case lintManifest || isManifest(file):
+		return lintManifestSchedules(file, f)
+	case lintSystem || isSystemCrontab(file):
+		return lintCrontab(file, f, true)
+	}
+	return lintCrontab(file, f, false)
+}
+
+func isManifest(file string) bool {
+	ext := filepath.Ext(file)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// isSystemCrontab reports whether `file` is /etc/crontab or one of the files
+// of /etc/cron.d, whose entries have a user column.
+func isSystemCrontab(file string) bool {
+	file = filepath.Clean(file)
+	return file == "/etc/crontab" || filepath.Dir(file) == "/etc/cron.d"
+}
+
+/******************************************************************************/
+
+var crontabEnvAssignment = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*\s*=`)
+
+// lintCrontab checks the entries of a crontab(5) file. Blank lines, comments
+// and environment assignments are skipped, any other line is an entry made
+// of a schedule, either five fields or a predefined expression, followed by a
+// user column in system crontabs, then by a command.
+func lintCrontab(file string, r io.Reader, system bool) ([]diagnostic, error) {
+	diagnostics := []diagnostic{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		fields := findWords(text)
+		if len(fields) == 0 || text[fields[0][0]] == '#' || crontabEnvAssignment.MatchString(text[fields[0][0]:]) {
+			continue
+		}
+		errorf := func(i int, format string, args ...interface{}) {
+			diagnostics = append(diagnostics, diagnostic{file, line, i + 1, severityError, fmt.Sprintf(format, args...)})
+		}
+
+		scheduleLen := 5
+		if text[fields[0][0]] == '@' {
+			scheduleLen = 1
+		}
+		if len(fields) < scheduleLen {
+			errorf(fields[0][0], "incomplete schedule, %d fields instead of %d", len(fields), scheduleLen)
+			continue
+		}
+		schedule := fields[0][0]
+		rest := fields[scheduleLen:]
+		if system {
+			if len(rest) == 0 {
+				errorf(len(text), "missing user")
+				continue
+			}
+			rest = rest[1:]
+		}
+		if len(rest) == 0 {
+			errorf(len(text), "missing command")
+			continue
+		}
+		if text[schedule:fields[scheduleLen-1][1]] == "@reboot" {
+			continue
+		}
+		diagnostics = append(diagnostics, lintSchedule(file, line, schedule+1, text[schedule:fields[scheduleLen-1][1]])...)
+	}
+	return diagnostics, scanner.Err()
+}
+
+// findWords returns the byte offsets of the beginning and end of each
+// whitespace-separated word of `s`.
+func findWords(s string) [][2]int {
+	words := [][2]int{}
+	for i := 0; i < len(s); {
+		for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+			i++
+		}
+		if i == len(s) {
+			break
+		}
+		j := i
+		for j < len(s) && s[j] != ' ' && s[j] != '\t' {
+			j++
+		}
+		words = append(words, [2]int{i, j})
+		i = j
+	}
+	return words
+}
+
+/******************************************************************************/
+
+var manifestSchedule = regexp.MustCompile(`^\s*(?:-\s+)?schedule:\s*`)
+
+// lintManifestSchedules checks the values of the `schedule` keys of a YAML
+// manifest, such as the spec of a Kubernetes CronJob. Values may be plain or
+// quoted, but must fit on the line of their key.
+func lintManifestSchedules(file string, r io.Reader) ([]diagnostic, error) {
+	diagnostics := []diagnostic{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		key := manifestSchedule.FindStringIndex(text)
+		if key == nil {
+			continue
+		}
+		beg, value := key[1], text[key[1]:]
+		switch {
+		case value == "" || value[0] == '#' || value[0] == '|' || value[0] == '>':
+			// Nested keys or block scalars
+			continue
+		case value[0] == '"' || value[0] == '\'':
+			end := strings.IndexByte(value[1:], value[0])
+			if end < 0 {
+				diagnostics = append(diagnostics, diagnostic{file, line, beg + 1, severityError, "unterminated quoted schedule"})
+				continue
+			}
+			beg, value = beg+1, value[1:end+1]
+		default:
+			if comment := strings.Index(value, " #"); comment >= 0 {
+				value = value[:comment]
+			}
+			value = strings.TrimRight(value, " \t")
+		}
+		diagnostics = append(diagnostics, lintSchedule(file, line, beg+1, value)...)
+	}
+	return diagnostics, scanner.Err()
+}
+
+/******************************************************************************/
+
+// lintSchedule parses the cron expression found at `line` and `col` of
+// `file`, and returns the parse error, at the field which fails to parse, or
+// the lint warnings about it.
+func lintSchedule(file string, line, col int, schedule string) []diagnostic {
+	expr, err := cronexpr.Parse(schedule)
+	if err != nil {
+		return []diagnostic{{file, line, col + invalidFieldOffset(schedule), severityError, fmt.Sprintf("\"%s\": %s", schedule, err)}}
+	}
+	diagnostics := []diagnostic{}
+	for _, w := range cronexpr.Lint(expr) {
+		diagnostics = append(diagnostics, diagnostic{file, line, col + w.Pos, severityWarning, fmt.Sprintf("[%s] %s", w.Code, w.Message)})
+	}
+	return diagnostics
+}
+
+// invalidFieldOffset returns the byte offset in `schedule` of the field which
+// fails to parse, that is the first one which still fails once all the
+// following fields are replaced with `*`, or 0 if there is none, i.e. when
+// fields are missing.
+func invalidFieldOffset(schedule string) int {
+	words := findWords(schedule)
+	if len(words) < 5 {
+		return 0
+	}
+	fields := make([]string, len(words))
+	for i := range words {
+		for j, word := range words {
+			if j <= i {
+				fields[j] = schedule[word[0]:word[1]]
+			} else {
+				fields[j] = "*"
+			}
+		}
+		if _, err := cronexpr.Parse(strings.Join(fields, " ")); err != nil {
+			return words[i][0]
+		}
+	}
+	return 0
+}
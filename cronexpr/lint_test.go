@@ -0,0 +1,92 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: lint_test.go
+ * Version: 1.0
+ * License: GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *
+ */
+
+package main
+
+/******************************************************************************/
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+/******************************************************************************/
+
+func diagnosticStrings(diagnostics []diagnostic) []string {
+	lines := []string{}
+	for _, d := range diagnostics {
+		lines = append(lines, d.String())
+	}
+	return lines
+}
+
+func TestLintCrontab(t *testing.T) {
+	crontab := strings.Join([]string{
+		"# m h dom mon dow user command",
+		"SHELL=/bin/sh",
+		"MAILTO = ops@example.com",
+		"",
+		"17 *\t* * *  root  cd / && run-parts --report /etc/cron.hourly",
+		"  0 0 31 2 * root /usr/bin/true",
+		"0 25 * * * root /usr/bin/true",
+		"@daily root /usr/bin/true",
+		"@reboot root /usr/bin/true",
+		"@fortnightly root /usr/bin/true",
+		"*/5 * * * * root",
+		"*/5 * * *",
+		"0 9 * jan 8 root /usr/bin/true",
+	}, "\n")
+
+	diagnostics, err := lintCrontab("cron.d/jobs", strings.NewReader(crontab), true)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"cron.d/jobs:6:7: warning: [never-fires] no day of the selected months matches, the expression never fires",
+		`cron.d/jobs:7:3: error: "0 25 * * *": syntax error in hour field: '25'`,
+		`cron.d/jobs:10:1: error: "@fortnightly": missing field(s)`,
+		"cron.d/jobs:11:17: error: missing command",
+		"cron.d/jobs:12:1: error: incomplete schedule, 4 fields instead of 5",
+		`cron.d/jobs:13:11: error: "0 9 * jan 8": syntax error in day-of-week field: '8'`,
+	}, diagnosticStrings(diagnostics))
+
+	// Without a user column, the user is taken for the command
+	diagnostics, err = lintCrontab("crontab", strings.NewReader("*/5 * * * * root\n*/5 * * * *\n"), false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"crontab:2:12: error: missing command"}, diagnosticStrings(diagnostics))
+}
+
+func TestLintManifest(t *testing.T) {
+	manifest := strings.Join([]string{
+		"apiVersion: batch/v1",
+		"kind: CronJob",
+		"spec:",
+		`  schedule: "*/7 * * * *"`,
+		"  jobTemplate:",
+		"---",
+		"spec:",
+		"  schedule: 0 0 30 2 *  # never",
+		"---",
+		"spec:",
+		"  schedule: '0 9 * * 8'",
+		"  schedule: \"0 9 * * *",
+		"  schedule:",
+		"    cron: not linted",
+	}, "\n")
+
+	diagnostics, err := lintManifestSchedules("cronjob.yaml", strings.NewReader(manifest))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"cronjob.yaml:4:14: warning: [uneven-step] step of 7 does not divide the 60 values of the minute field, the gap between 56 and 0 is 4",
+		"cronjob.yaml:8:17: warning: [never-fires] no day of the selected months matches, the expression never fires",
+		`cronjob.yaml:11:22: error: "0 9 * * 8": syntax error in day-of-week field: '8'`,
+		"cronjob.yaml:12:13: error: unterminated quoted schedule",
+	}, diagnosticStrings(diagnostics))
+}
@@ -15,44 +15,131 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"time"
-
-	"github.com/gorhill/cronexpr"
 )
 
 /******************************************************************************/
 
-var (
-	usage = func() {
-		fmt.Fprintf(os.Stderr, "usage:\n  %s [options] \"{cron expression}\"\noptions:\n", os.Args[0])
-		flag.PrintDefaults()
-	}
-	inTimeStr     string
-	outTimeCount  uint
-	outTimeLayout string
+// A command is one of the subcommands of the utility, i.e. `explain` in
+// `cronexpr explain "0 9 * * 1-5"`.
+type command struct {
+	name string
+	// Synopsis of the arguments, i.e. `"{cron expression}"`
+	args    string
+	summary string
+	// What the arguments are, for shell completion
+	complete string
+	// setFlags declares the options of the command
+	setFlags func(fs *flag.FlagSet)
+	// run runs the command with its parsed options and arguments, and
+	// returns the exit status
+	run func(fs *flag.FlagSet) int
+}
+
+// Kinds of arguments of commands
+const (
+	completeExpressions = "expressions"
+	completeFiles       = "files"
+	completeShells      = "shells"
 )
 
+// commands lists the subcommands, the first one being run when no
+// subcommand is named. It is set on initialization, since some commands
+// refer to it.
+var commands []*command
+
+func init() {
+	commands = []*command{
+		nextCommand,
+		explainCommand,
+		lintCommand,
+		calCommand,
+		diffCommand,
+		toSystemdCommand,
+		fromSystemdCommand,
+		replCommand,
+		genCompletionCommand,
+		genManCommand,
+	}
+}
+
+func findCommand(name string) *command {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
 /******************************************************************************/
 
 func main() {
-	var err error
-
-	flag.Usage = usage
-	flag.StringVar(&inTimeStr, "t", "", `whole or partial RFC3339 time value (i.e. "2006-01-02T15:04:05Z07:00") against which the cron expression is evaluated, now if not present`)
-	flag.UintVar(&outTimeCount, "n", 1, `number of resulting time values to output`)
-	flag.StringVar(&outTimeLayout, "l", "Mon, 02 Jan 2006 15:04:05 MST", `Go-compliant time layout to use for outputting time value(s), see <http://golang.org/pkg/time/#pkg-constants>`)
-	flag.Parse()
+	args := os.Args[1:]
+	cmd, named := commands[0], false
+	if len(args) > 0 {
+		if args[0] == "help" {
+			usage(os.Stdout)
+			return
+		}
+		if c := findCommand(args[0]); c != nil {
+			cmd, named, args = c, true, args[1:]
+		}
+	}
 
-	cronStr := flag.Arg(0)
-	if len(cronStr) == 0 {
-		flag.Usage()
+	fs := flag.NewFlagSet(os.Args[0]+" "+cmd.name, flag.ExitOnError)
+	fs.Usage = func() {
+		if !named {
+			usage(os.Stderr)
+			return
+		}
+		fmt.Fprintf(os.Stderr, "usage:\n  %s %s [options] %s\n%s\n", os.Args[0], cmd.name, cmd.args, cmd.summary)
+		hasFlags := false
+		fs.VisitAll(func(*flag.Flag) { hasFlags = true })
+		if hasFlags {
+			fmt.Fprintln(os.Stderr, "options:")
+			fs.PrintDefaults()
+		}
+	}
+	cmd.setFlags(fs)
+	fs.Parse(args)
+	if fs.NArg() == 0 && cmd.args != "" {
+		fs.Usage()
 		return
 	}
+	os.Exit(cmd.run(fs))
+}
+
+// usage prints the synopsis of all the subcommands, followed by the options
+// of the default one.
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "usage:\n  %s [command] [options] %s\ncommands:\n", os.Args[0], commands[0].args)
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-14s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(w, "options of %s, the default command:\n", commands[0].name)
+	fs := flag.NewFlagSet(commands[0].name, flag.ContinueOnError)
+	fs.SetOutput(w)
+	commands[0].setFlags(fs)
+	fs.PrintDefaults()
+}
+
+/******************************************************************************/
+
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "# error: "+format+"\n", args...)
+	os.Exit(1)
+}
 
-	inTime := time.Now()
+// parseTime parses a whole or partial RFC3339 time value, i.e. `2013`,
+// `2013-08-31T12` or `2013-08-31T12:40:35-10:00`, in the `loc` time zone
+// unless the offset is part of the value. `defaultTime` is returned for an
+// empty value.
+func parseTime(timeStr string, defaultTime time.Time, loc *time.Location) (time.Time, error) {
 	inTimeLayout := ""
-	timeStrLen := len(inTimeStr)
+	timeStrLen := len(timeStr)
 	if timeStrLen == 2 {
 		inTimeLayout = "06"
 	} else if timeStrLen >= 4 {
@@ -77,35 +164,22 @@ func main() {
 		}
 	}
 
-	if len(inTimeLayout) > 0 {
-		// default to local time zone
-		if timeStrLen < 20 {
-			inTime, err = time.ParseInLocation(inTimeLayout, inTimeStr, time.Local)
-		} else {
-			inTime, err = time.Parse(inTimeLayout, inTimeStr)
-		}
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "# error: unparseable time value: \"%s\"\n", inTimeStr)
-			os.Exit(1)
+	if len(inTimeLayout) == 0 {
+		if timeStrLen > 0 {
+			return time.Time{}, fmt.Errorf("unparseable time value: \"%s\"", timeStr)
 		}
+		return defaultTime, nil
 	}
-
-	expr, err := cronexpr.Parse(cronStr)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "# %s: %s\n", os.Args[0], err)
-		os.Exit(1)
-	}
-
-	// Anything on the output which starts with '#' can be ignored if the caller
-	// is interested only in the time values. There is only one time
-	// value per line, and they are always in chronological ascending order.
-	fmt.Printf("# \"%s\" + \"%s\" =\n", cronStr, inTime.Format(time.RFC3339))
-
-	if outTimeCount < 1 {
-		outTimeCount = 1
+	var t time.Time
+	var err error
+	// default to the time zone of the caller
+	if timeStrLen < 20 {
+		t, err = time.ParseInLocation(inTimeLayout, timeStr, loc)
+	} else {
+		t, err = time.Parse(inTimeLayout, timeStr)
 	}
-	outTimes := expr.NextN(inTime, outTimeCount)
-	for _, outTime := range outTimes {
-		fmt.Println(outTime.Format(outTimeLayout))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unparseable time value: \"%s\"", timeStr)
 	}
+	return t, nil
 }
@@ -0,0 +1,179 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: next.go
+ * Version: 1.0
+ * License: GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *
+ */
+
+package main
+
+/******************************************************************************/
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/thought-machine/cronexpr"
+)
+
+/******************************************************************************/
+
+var nextCommand = &command{
+	name:     "next",
+	args:     `"{cron expression}"`,
+	summary:  "output the time values matching a cron expression",
+	complete: completeExpressions,
+	setFlags: setNextFlags,
+	run:      runNext,
+}
+
+var (
+	inTimeStr     string
+	fromTimeStr   string
+	toTimeStr     string
+	outTimeCount  uint
+	prevTimeCount uint
+	outTimeLayout string
+	outFormat     string
+	timeZone      zoneFlag
+	showTimeZones zoneListFlag
+)
+
+func setNextFlags(fs *flag.FlagSet) {
+	fs.StringVar(&inTimeStr, "t", "", `whole or partial RFC3339 time value (i.e. "2006-01-02T15:04:05Z07:00") against which the cron expression is evaluated, now if not present`)
+	fs.UintVar(&outTimeCount, "n", 1, `number of resulting time values to output`)
+	fs.UintVar(&prevTimeCount, "prev", 0, `number of resulting time values to output before the -t time value, instead of after it`)
+	fs.StringVar(&fromTimeStr, "from", "", `whole or partial RFC3339 time value from which to output all resulting time values, included, instead of -t and -n`)
+	fs.StringVar(&toTimeStr, "to", "", `whole or partial RFC3339 time value up to which to output all resulting time values, included`)
+	fs.StringVar(&outTimeLayout, "l", "Mon, 02 Jan 2006 15:04:05 MST", `Go-compliant time layout to use for outputting time value(s), see <http://golang.org/pkg/time/#pkg-constants>`)
+	fs.StringVar(&outFormat, "o", outputText, `output format, one of "text", "json", "ndjson" or "csv"`)
+	fs.Var(&timeZone, "tz", `IANA time zone (i.e. "Europe/Paris") in which the cron expression is evaluated, local time zone if not present`)
+	fs.Var(&showTimeZones, "show-tz", `IANA time zone in which to also output each resulting time value, side by side, along with the time zones where it falls on a day of daylight saving transition; can be repeated`)
+}
+
+/******************************************************************************/
+
+func runNext(fs *flag.FlagSet) int {
+	cronStr := fs.Arg(0)
+
+	loc := timeZone.location()
+	inTime, err := parseTime(inTimeStr, time.Now(), loc)
+	if err != nil {
+		fatalf("%s", err)
+	}
+	fromTime, err := parseTime(fromTimeStr, inTime, loc)
+	if err != nil {
+		fatalf("%s", err)
+	}
+	toTime, err := parseTime(toTimeStr, time.Time{}, loc)
+	if err != nil {
+		fatalf("%s", err)
+	}
+	// An explicit time zone prevails over the offset of time values
+	if timeZone.loc != nil {
+		inTime, fromTime, toTime = inTime.In(loc), fromTime.In(loc), toTime.In(loc)
+	}
+	if fromTimeStr != "" && toTimeStr == "" {
+		fatalf("-from requires -to")
+	}
+	if prevTimeCount > 0 && toTimeStr != "" {
+		fatalf("-prev cannot be combined with -from and -to")
+	}
+
+	expr, err := cronexpr.Parse(cronStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "# %s: %s\n", os.Args[0], err)
+		return 1
+	}
+
+	out, err := newPrinter(os.Stdout, outFormat, outTimeLayout, showTimeZones)
+	if err != nil {
+		fatalf("%s", err)
+	}
+
+	// Anything on the text output which starts with '#' can be ignored if the
+	// caller is interested only in the time values. There is only one time
+	// value per line, unless other time zones are shown, and they are always
+	// in chronological ascending order.
+	switch {
+	case toTimeStr != "":
+		err = out.begin(fmt.Sprintf("\"%s\" in [\"%s\", \"%s\"] =", cronStr, fromTime.Format(time.RFC3339), toTime.Format(time.RFC3339)))
+		// Next excludes the time value it is given, whereas the range
+		// includes `fromTime`
+		for t := expr.Next(fromTime.Add(-time.Nanosecond)); err == nil && !t.IsZero() && !t.After(toTime); t = expr.Next(t) {
+			err = out.print(t)
+		}
+	case prevTimeCount > 0:
+		err = out.begin(fmt.Sprintf("\"%s\" - \"%s\" =", cronStr, inTime.Format(time.RFC3339)))
+		for _, t := range prevN(expr, inTime, prevTimeCount) {
+			if err == nil {
+				err = out.print(t)
+			}
+		}
+	default:
+		err = out.begin(fmt.Sprintf("\"%s\" + \"%s\" =", cronStr, inTime.Format(time.RFC3339)))
+		if outTimeCount < 1 {
+			outTimeCount = 1
+		}
+		for _, t := range expr.NextN(inTime, outTimeCount) {
+			if err == nil {
+				err = out.print(t)
+			}
+		}
+	}
+	if err == nil {
+		err = out.end()
+	}
+	if err != nil {
+		fatalf("%s", err)
+	}
+	return 0
+}
+
+/******************************************************************************/
+
+// prevN returns, in chronological ascending order, up to `n` time values
+// matching `expr` immediately preceding `toTime`, excluded.
+func prevN(expr *cronexpr.Expression, toTime time.Time, n uint) []time.Time {
+	times := make([]time.Time, 0, n)
+	for t := prev(expr, toTime); !t.IsZero() && uint(len(times)) < n; t = prev(expr, t) {
+		times = append(times, t)
+	}
+	for i, j := 0, len(times)-1; i < j; i, j = i+1, j-1 {
+		times[i], times[j] = times[j], times[i]
+	}
+	return times
+}
+
+// prev returns the last time value matching `expr` before `toTime`, or the
+// zero time if there is none. Since Next(t) only moves forward as `t` does,
+// the last time value is Next(t) for the latest `t` whose Next(t) is before
+// `toTime`, which is found by looking ever further back, then by bisection.
+func prev(expr *cronexpr.Expression, toTime time.Time) time.Time {
+	earliest := time.Date(1970, time.January, 1, 0, 0, 0, 0, toTime.Location())
+	before := func(t time.Time) bool {
+		next := expr.Next(t)
+		return !next.IsZero() && next.Before(toTime)
+	}
+	lo, hi := toTime, toTime
+	for window := time.Minute; !before(lo); window *= 2 {
+		if lo.Before(earliest) {
+			return time.Time{}
+		}
+		hi, lo = lo, toTime.Add(-window)
+	}
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2)
+		if before(mid) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return expr.Next(lo)
+}
@@ -0,0 +1,187 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: output.go
+ * Version: 1.0
+ * License: GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *
+ */
+
+package main
+
+/******************************************************************************/
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/******************************************************************************/
+
+// Output formats, as selected with `-o`
+const (
+	outputText   = "text"
+	outputJSON   = "json"
+	outputNDJSON = "ndjson"
+	outputCSV    = "csv"
+)
+
+var outputFormats = []string{outputText, outputJSON, outputNDJSON, outputCSV}
+
+// A timeRecord is the machine-readable form of a time value.
+type timeRecord struct {
+	Time   string `json:"time"`
+	Unix   int64  `json:"unix"`
+	Zone   string `json:"zone"`
+	Offset string `json:"offset"`
+	// Name of the time zone, for the time value in other time zones
+	Location string `json:"location,omitempty"`
+	// Whether the time value falls on a day of daylight saving transition,
+	// and the time value in other time zones, when any are shown
+	DSTTransition bool         `json:"dst_transition,omitempty"`
+	Zones         []timeRecord `json:"zones,omitempty"`
+}
+
+func newTimeRecord(t time.Time) timeRecord {
+	zone, _ := t.Zone()
+	return timeRecord{
+		Time:   t.Format(time.RFC3339),
+		Unix:   t.Unix(),
+		Zone:   zone,
+		Offset: t.Format("-07:00"),
+	}
+}
+
+// newZonedTimeRecord returns the record of `t` and of `t` in each of
+// `zones`.
+func newZonedTimeRecord(t time.Time, zones []*time.Location) timeRecord {
+	record := newTimeRecord(t)
+	record.DSTTransition = dstTransitionDay(t)
+	for _, loc := range zones {
+		zoned := newTimeRecord(t.In(loc))
+		zoned.Location = loc.String()
+		zoned.DSTTransition = dstTransitionDay(t.In(loc))
+		record.Zones = append(record.Zones, zoned)
+	}
+	return record
+}
+
+/******************************************************************************/
+
+// A printer writes time values to its output, one at a time, in one of the
+// output formats. Time values are written as they are computed, so that
+// long series of them need not be held in memory.
+type printer struct {
+	w      io.Writer
+	format string
+	// Go-compliant time layout of the text format
+	layout string
+	// Other time zones in which to write each time value, side by side,
+	// along with those of all the time zones where it falls on a day of
+	// daylight saving transition
+	zones []*time.Location
+	count int
+	csv   *csv.Writer
+}
+
+func newPrinter(w io.Writer, format, layout string, zones []*time.Location) (*printer, error) {
+	for _, f := range outputFormats {
+		if f == format {
+			return &printer{w: w, format: format, layout: layout, zones: zones}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown output format: \"%s\"", format)
+}
+
+// begin starts the output. The text format starts with a `#` comment line
+// made of `comment`, which other formats ignore.
+func (p *printer) begin(comment string) error {
+	var err error
+	switch p.format {
+	case outputText:
+		_, err = fmt.Fprintf(p.w, "# %s\n", comment)
+	case outputJSON:
+		_, err = io.WriteString(p.w, "[")
+	case outputCSV:
+		p.csv = csv.NewWriter(p.w)
+		header := []string{"time", "unix", "zone", "offset"}
+		if len(p.zones) > 0 {
+			for _, loc := range p.zones {
+				header = append(header, loc.String())
+			}
+			header = append(header, "dst_transitions")
+		}
+		err = p.csv.Write(header)
+	}
+	return err
+}
+
+func (p *printer) print(t time.Time) error {
+	var err error
+	record := newTimeRecord(t)
+	if len(p.zones) > 0 {
+		record = newZonedTimeRecord(t, p.zones)
+	}
+	switch p.format {
+	case outputText:
+		if len(p.zones) == 0 {
+			_, err = fmt.Fprintln(p.w, t.Format(p.layout))
+			break
+		}
+		columns := []string{t.Format(p.layout)}
+		for _, loc := range p.zones {
+			columns = append(columns, t.In(loc).Format(p.layout))
+		}
+		if transitions := dstTransitionZones(t, p.zones); len(transitions) > 0 {
+			columns = append(columns, fmt.Sprintf("(DST transition in %s)", strings.Join(transitions, ", ")))
+		}
+		_, err = fmt.Fprintln(p.w, strings.Join(columns, "  "))
+	case outputJSON, outputNDJSON:
+		var b []byte
+		if b, err = json.Marshal(record); err != nil {
+			return err
+		}
+		prefix, suffix := "", "\n"
+		if p.format == outputJSON {
+			prefix, suffix = "\n  ", ""
+			if p.count > 0 {
+				prefix = "," + prefix
+			}
+		}
+		_, err = fmt.Fprintf(p.w, "%s%s%s", prefix, b, suffix)
+	case outputCSV:
+		row := []string{record.Time, strconv.FormatInt(record.Unix, 10), record.Zone, record.Offset}
+		if len(p.zones) > 0 {
+			for _, zoned := range record.Zones {
+				row = append(row, zoned.Time)
+			}
+			row = append(row, strings.Join(dstTransitionZones(t, p.zones), " "))
+		}
+		err = p.csv.Write(row)
+	}
+	p.count++
+	return err
+}
+
+// end completes the output.
+func (p *printer) end() error {
+	var err error
+	switch p.format {
+	case outputJSON:
+		if p.count > 0 {
+			_, err = io.WriteString(p.w, "\n]\n")
+		} else {
+			_, err = io.WriteString(p.w, "]\n")
+		}
+	case outputCSV:
+		p.csv.Flush()
+		err = p.csv.Error()
+	}
+	return err
+}
@@ -0,0 +1,167 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: repl.go
+ * Version: 1.0
+ * License: GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *
+ */
+
+package main
+
+/******************************************************************************/
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thought-machine/cronexpr"
+)
+
+/******************************************************************************/
+
+var replCommand = &command{
+	name:     "repl",
+	summary:  "evaluate cron expressions read line by line",
+	setFlags: setReplFlags,
+	run:      runRepl,
+}
+
+var replOptions replState
+
+func setReplFlags(fs *flag.FlagSet) {
+	fs.StringVar(&replOptions.fromStr, "t", "", `whole or partial RFC3339 time value against which cron expressions are evaluated, now if not present`)
+	fs.UintVar(&replOptions.n, "n", 5, `number of resulting time values to output`)
+	fs.Var(&replOptions.tz, "tz", `IANA time zone (i.e. "Europe/Paris") in which cron expressions are evaluated, local time zone if not present`)
+	fs.StringVar(&replOptions.layout, "l", "Mon, 02 Jan 2006 15:04:05 MST", `Go-compliant time layout to use for outputting time values, see <http://golang.org/pkg/time/#pkg-constants>`)
+}
+
+func runRepl(fs *flag.FlagSet) int {
+	if _, err := parseTime(replOptions.fromStr, time.Time{}, replOptions.tz.location()); err != nil {
+		fatalf("%s", err)
+	}
+	if err := repl(os.Stdin, os.Stdout, isTerminal(os.Stdin), &replOptions); err != nil {
+		fatalf("%s", err)
+	}
+	return 0
+}
+
+/******************************************************************************/
+
+// replState holds the settings of the REPL, which its commands change.
+type replState struct {
+	// Time value against which cron expressions are evaluated, as given to
+	// `:from`, now if empty
+	fromStr string
+	tz      zoneFlag
+	n       uint
+	layout  string
+}
+
+const replHelp = `enter a cron expression to output its next time values, its description and the warnings about it, or a command:
+  :from {time}   evaluate cron expressions against a whole or partial RFC3339 time value, or now if none is given
+  :tz {zone}     evaluate cron expressions in an IANA time zone, i.e. Europe/Paris, or Local
+  :n {count}     output that many time values
+  :help          output this help
+  :quit          exit, as does end of input`
+
+// repl reads cron expressions and commands line by line from `r` until
+// `:quit` or the end of input, and writes its answers to `w`, preceded by
+// a prompt if `prompt` is set.
+func repl(r io.Reader, w io.Writer, prompt bool, state *replState) error {
+	scanner := bufio.NewScanner(r)
+	if prompt {
+		fmt.Fprintln(w, "# enter a cron expression, or :help")
+	}
+	for {
+		if prompt {
+			fmt.Fprint(w, "> ")
+		}
+		if !scanner.Scan() {
+			break
+		}
+		line := strings.TrimSpace(scanner.Text())
+		var err error
+		switch {
+		case line == "":
+			continue
+		case line == ":quit" || line == ":q":
+			return nil
+		case strings.HasPrefix(line, ":"):
+			err = state.command(w, line)
+		default:
+			err = state.evaluate(w, line)
+		}
+		if err != nil {
+			fmt.Fprintf(w, "error: %s\n", err)
+		}
+	}
+	if prompt {
+		fmt.Fprintln(w)
+	}
+	return scanner.Err()
+}
+
+// command runs a REPL command, i.e. `:n 20`.
+func (state *replState) command(w io.Writer, line string) error {
+	name, arg := line, ""
+	if i := strings.IndexAny(line, " \t"); i >= 0 {
+		name, arg = line[:i], strings.TrimSpace(line[i:])
+	}
+	switch name {
+	case ":from":
+		if _, err := parseTime(arg, time.Time{}, state.tz.location()); err != nil {
+			return err
+		}
+		state.fromStr = arg
+	case ":tz":
+		if arg == "" {
+			return fmt.Errorf(":tz requires a time zone")
+		}
+		if err := state.tz.Set(arg); err != nil {
+			return err
+		}
+	case ":n":
+		n, err := strconv.ParseUint(arg, 10, 0)
+		if err != nil || n < 1 {
+			return fmt.Errorf(":n requires a positive count: \"%s\"", arg)
+		}
+		state.n = uint(n)
+	case ":help", ":h", ":?":
+		fmt.Fprintln(w, replHelp)
+	default:
+		return fmt.Errorf("unknown command: \"%s\", see :help", name)
+	}
+	return nil
+}
+
+// evaluate writes the description of a cron expression, its next time values
+// and the warnings about it.
+func (state *replState) evaluate(w io.Writer, cronStr string) error {
+	expr, err := cronexpr.Parse(cronStr)
+	if err != nil {
+		return err
+	}
+	loc := state.tz.location()
+	fromTime, err := parseTime(state.fromStr, time.Now(), loc)
+	if err != nil {
+		return err
+	}
+	fromTime = fromTime.In(loc)
+
+	fmt.Fprintf(w, "# %s\n", cronexpr.Describe(expr))
+	for _, t := range expr.NextN(fromTime, state.n) {
+		fmt.Fprintln(w, t.Format(state.layout))
+	}
+	for _, warning := range cronexpr.Lint(expr) {
+		fmt.Fprintf(w, "warning: %s\n", warning)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: repl_test.go
+ * Version: 1.0
+ * License: GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *
+ */
+
+package main
+
+/******************************************************************************/
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+/******************************************************************************/
+
+func TestRepl(t *testing.T) {
+	input := strings.Join([]string{
+		":from 2027-03-26T00:00:00Z",
+		":tz Europe/Paris",
+		":n 3",
+		"",
+		"0 9 * * 1-5",
+		"0 0 31 * 1",
+		":n 0",
+		":tz Mars/Olympus",
+		":from yesterday",
+		"0 0 * *",
+		":bogus",
+		":quit",
+		"0 0 * * *",
+	}, "\n")
+	state := replState{n: 5, layout: "Mon 02 Jan 15:04 MST"}
+
+	var b strings.Builder
+	require.NoError(t, repl(strings.NewReader(input), &b, false, &state))
+	require.Equal(t, strings.Join([]string{
+		"# At 09:00, on Monday through Friday",
+		"Fri 26 Mar 09:00 CET",
+		"Mon 29 Mar 09:00 CEST",
+		"Tue 30 Mar 09:00 CEST",
+		"# At 00:00, on day 31 of the month or on Monday",
+		"Mon 29 Mar 00:00 CEST",
+		"Wed 31 Mar 00:00 CEST",
+		"Mon 05 Apr 00:00 CEST",
+		"warning: 4: [dom-or-dow] both day of month and day of week are restricted, the expression fires on days matching either of them",
+		`error: :n requires a positive count: "0"`,
+		"error: unknown time zone Mars/Olympus",
+		`error: unparseable time value: "yesterday"`,
+		"error: missing field(s)",
+		`error: unknown command: ":bogus", see :help`,
+		"",
+	}, "\n"), b.String())
+	require.Equal(t, uint(3), state.n)
+	require.Equal(t, "Europe/Paris", state.tz.String())
+
+	b.Reset()
+	require.NoError(t, repl(strings.NewReader("0 0 30 2 *\n"), &b, true, &state))
+	require.Equal(t, "# enter a cron expression, or :help\n> # Never, as no time instant matches\n"+
+		"warning: 4: [never-fires] no day of the selected months matches, the expression never fires\n> \n", b.String())
+}
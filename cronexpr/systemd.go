@@ -0,0 +1,63 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: systemd.go
+ * Version: 1.0
+ * License: GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *
+ */
+
+package main
+
+/******************************************************************************/
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/thought-machine/cronexpr"
+)
+
+/******************************************************************************/
+
+var toSystemdCommand = &command{
+	name:     "to-systemd",
+	args:     `"{cron expression}"`,
+	summary:  "convert a cron expression into a systemd OnCalendar= specification",
+	complete: completeExpressions,
+	setFlags: func(fs *flag.FlagSet) {},
+	run:      runToSystemd,
+}
+
+var fromSystemdCommand = &command{
+	name:     "from-systemd",
+	args:     `"{OnCalendar specification}"`,
+	summary:  "convert a systemd OnCalendar= specification into a cron expression",
+	setFlags: func(fs *flag.FlagSet) {},
+	run:      runFromSystemd,
+}
+
+func runToSystemd(fs *flag.FlagSet) int {
+	expr, err := cronexpr.Parse(fs.Arg(0))
+	if err == nil {
+		var onCalendar string
+		if onCalendar, err = expr.OnCalendar(); err == nil {
+			fmt.Println(onCalendar)
+			return 0
+		}
+	}
+	fmt.Fprintf(os.Stderr, "# %s: %s\n", os.Args[0], err)
+	return 1
+}
+
+func runFromSystemd(fs *flag.FlagSet) int {
+	cronStr, err := cronexpr.OnCalendarToCron(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "# %s: %s\n", os.Args[0], err)
+		return 1
+	}
+	fmt.Println(cronStr)
+	return 0
+}
@@ -0,0 +1,98 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: zones.go
+ * Version: 1.0
+ * License: GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *
+ */
+
+package main
+
+/******************************************************************************/
+
+import (
+	"strings"
+	"time"
+)
+
+/******************************************************************************/
+
+// A zoneFlag is the value of an option naming an IANA time zone, i.e.
+// `Europe/Paris`, or `Local` or `UTC`.
+type zoneFlag struct {
+	loc *time.Location
+}
+
+func (z *zoneFlag) String() string {
+	if z.loc == nil {
+		return ""
+	}
+	return z.loc.String()
+}
+
+func (z *zoneFlag) Set(name string) error {
+	loc, err := time.LoadLocation(name)
+	if err == nil {
+		z.loc = loc
+	}
+	return err
+}
+
+// location returns the named time zone, or the local time zone if none was
+// named.
+func (z *zoneFlag) location() *time.Location {
+	if z.loc == nil {
+		return time.Local
+	}
+	return z.loc
+}
+
+// A zoneListFlag is the value of a repeatable option naming an IANA time
+// zone.
+type zoneListFlag []*time.Location
+
+func (z *zoneListFlag) String() string {
+	names := []string{}
+	for _, loc := range *z {
+		names = append(names, loc.String())
+	}
+	return strings.Join(names, ",")
+}
+
+func (z *zoneListFlag) Set(name string) error {
+	loc, err := time.LoadLocation(name)
+	if err == nil {
+		*z = append(*z, loc)
+	}
+	return err
+}
+
+/******************************************************************************/
+
+// dstTransitionDay reports whether the UTC offset of the time zone of `t`
+// changes during the day of `t` in that time zone, as it does on the days
+// daylight saving time begins or ends.
+func dstTransitionDay(t time.Time) bool {
+	y, m, d := t.Date()
+	begin := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	end := time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+	_, beginOffset := begin.Zone()
+	_, endOffset := end.Add(-time.Nanosecond).Zone()
+	return beginOffset != endOffset
+}
+
+// dstTransitionZones returns the names of the time zones, among that of `t`
+// and `zones`, in which `t` falls on a day of daylight saving transition.
+func dstTransitionZones(t time.Time, zones []*time.Location) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, loc := range append([]*time.Location{t.Location()}, zones...) {
+		if !seen[loc.String()] && dstTransitionDay(t.In(loc)) {
+			names = append(names, loc.String())
+		}
+		seen[loc.String()] = true
+	}
+	return names
+}
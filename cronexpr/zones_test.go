@@ -0,0 +1,63 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: zones_test.go
+ * Version: 1.0
+ * License: GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *
+ */
+
+package main
+
+/******************************************************************************/
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+/******************************************************************************/
+
+func TestDSTTransitionDay(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+
+	require.True(t, dstTransitionDay(time.Date(2027, time.March, 14, 12, 0, 0, 0, newYork)))
+	require.True(t, dstTransitionDay(time.Date(2027, time.November, 7, 0, 30, 0, 0, newYork)))
+	require.False(t, dstTransitionDay(time.Date(2027, time.March, 13, 23, 59, 0, 0, newYork)))
+	require.False(t, dstTransitionDay(time.Date(2027, time.March, 14, 12, 0, 0, 0, time.UTC)))
+
+	// 09:00 in New York on the day Europe moves to summer time
+	sunday := time.Date(2027, time.March, 28, 9, 0, 0, 0, newYork)
+	require.Equal(t, []string{"Europe/London"}, dstTransitionZones(sunday, []*time.Location{london, time.UTC}))
+	require.Equal(t, []string{}, dstTransitionZones(sunday.AddDate(0, 0, 7), []*time.Location{london}))
+	require.Equal(t, []string{"America/New_York"}, dstTransitionZones(time.Date(2027, time.March, 14, 9, 0, 0, 0, newYork), []*time.Location{newYork}))
+}
+
+func TestPrinter_ShowZones(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+	sunday := time.Date(2027, time.March, 28, 9, 0, 0, 0, newYork)
+
+	var b strings.Builder
+	out, err := newPrinter(&b, outputText, "Mon 15:04 MST", []*time.Location{london})
+	require.NoError(t, err)
+	require.NoError(t, out.print(sunday))
+	require.NoError(t, out.print(sunday.AddDate(0, 0, 7)))
+	require.Equal(t, "Sun 09:00 EDT  Sun 14:00 BST  (DST transition in Europe/London)\nSun 09:00 EDT  Sun 14:00 BST\n", b.String())
+
+	b.Reset()
+	out, err = newPrinter(&b, outputNDJSON, "", []*time.Location{london})
+	require.NoError(t, err)
+	require.NoError(t, out.print(sunday))
+	require.Equal(t, `{"time":"2027-03-28T09:00:00-04:00","unix":1806238800,"zone":"EDT","offset":"-04:00",`+
+		`"zones":[{"time":"2027-03-28T14:00:00+01:00","unix":1806238800,"zone":"BST","offset":"+01:00","location":"Europe/London","dst_transition":true}]}`+"\n", b.String())
+}
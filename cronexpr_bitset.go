@@ -0,0 +1,80 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_bitset.go
+ * Version: 1.0
+ * License: pick the one which suits you :
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"math/bits"
+)
+
+/******************************************************************************/
+
+// A bitset is a set of values in the range [0-63], value `v` being a member
+// of the set when bit `v` is set. Seconds, minutes, hours, days of month,
+// months and days of week all fit in a bitset, so that matching them needs
+// neither memory allocation nor searching.
+type bitset uint64
+
+func bitsetOf(list []int) bitset {
+	var set bitset
+	for _, v := range list {
+		set.add(v)
+	}
+	return set
+}
+
+func (set *bitset) add(v int) {
+	*set |= 1 << uint(v)
+}
+
+func (set *bitset) addRange(min, max, step int) {
+	for v := min; v <= max; v += step {
+		set.add(v)
+	}
+}
+
+func (set bitset) has(v int) bool {
+	return set&(1<<uint(v)) != 0
+}
+
+// next returns the smallest member of the set which is greater than or equal
+// to `v`, or -1 if there is none.
+func (set bitset) next(v int) int {
+	if v >= 64 {
+		return -1
+	}
+	rest := set >> uint(v)
+	if rest == 0 {
+		return -1
+	}
+	return v + bits.TrailingZeros64(uint64(rest))
+}
+
+// first returns the smallest member of the set, or -1 if the set is empty.
+func (set bitset) first() int {
+	return set.next(0)
+}
+
+func (set bitset) count() int {
+	return bits.OnesCount64(uint64(set))
+}
+
+// list returns the members of the set in ascending order.
+func (set bitset) list() []int {
+	list := make([]int, 0, set.count())
+	for v := set.first(); v >= 0; v = set.next(v + 1) {
+		list = append(list, v)
+	}
+	return list
+}
@@ -0,0 +1,122 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_cache.go
+ * Version: 1.0
+ * License: pick the one which suits you :
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+)
+
+/******************************************************************************/
+
+// A Cache holds the most recently parsed cron expressions, so that parsing
+// the same expression again returns the Expression pointer parsed the first
+// time. Since an Expression is not modified once parsed, the returned
+// pointer can be shared by several goroutines.
+//
+// A Cache can be used by several goroutines at once.
+type Cache struct {
+	mutex   sync.Mutex
+	size    int
+	options []ParseOption
+	entries map[string]*list.Element
+	// Most recently used entries first
+	lru *list.List
+}
+
+type cacheEntry struct {
+	cronLine string
+	expr     *Expression
+	err      error
+}
+
+/******************************************************************************/
+
+// NewCache returns a new Cache which holds up to `size` expressions, parsed
+// with the supplied options. The least recently used expression is evicted
+// when the cache is full. NewCache panics if `size` is not positive.
+//
+// Options are applied when an expression is first parsed, so that an
+// expression parsed with Strict stays cached even after it can no longer
+// fire.
+func NewCache(size int, options ...ParseOption) *Cache {
+	if size < 1 {
+		panic("cronexpr: cache size must be positive")
+	}
+	return &Cache{
+		size:    size,
+		options: options,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+/******************************************************************************/
+
+// Parse returns the Expression pointer for `cronLine`, parsing it only if
+// it is not already cached. Cron expressions are cached in a normalized form,
+// where fields are separated by a single space, so that `0 0 * * *` and
+// ` 0  0 * * *` share the same Expression, parsed from the normalized form.
+// Malformed cron expressions are cached as well, along with their error.
+func (c *Cache) Parse(cronLine string) (*Expression, error) {
+	cronLine = strings.Join(strings.Fields(cronLine), " ")
+
+	c.mutex.Lock()
+	if element, ok := c.entries[cronLine]; ok {
+		c.lru.MoveToFront(element)
+		entry := element.Value.(*cacheEntry)
+		c.mutex.Unlock()
+		return entry.expr, entry.err
+	}
+	c.mutex.Unlock()
+
+	// Parse without holding the lock, so that a cache miss does not delay
+	// the goroutines looking up other expressions
+	expr, err := ParseWithOptions(cronLine, c.options...)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	// Another goroutine may have parsed the same expression meanwhile, in
+	// which case its Expression is the one to share
+	if element, ok := c.entries[cronLine]; ok {
+		c.lru.MoveToFront(element)
+		entry := element.Value.(*cacheEntry)
+		return entry.expr, entry.err
+	}
+	c.entries[cronLine] = c.lru.PushFront(&cacheEntry{cronLine: cronLine, expr: expr, err: err})
+	if c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).cronLine)
+	}
+	return expr, err
+}
+
+// MustParse is like Parse but panics if the cron expression is malformed.
+func (c *Cache) MustParse(cronLine string) *Expression {
+	expr, err := c.Parse(cronLine)
+	if err != nil {
+		panic(err)
+	}
+	return expr
+}
+
+// Len returns the number of cron expressions in the cache.
+func (c *Cache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.lru.Len()
+}
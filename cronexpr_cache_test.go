@@ -0,0 +1,99 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_cache_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+/******************************************************************************/
+
+func TestCache(t *testing.T) {
+	cache := NewCache(2)
+	daily := cache.MustParse("0 0 * * *")
+	require.Same(t, daily, cache.MustParse("0 0 * * *"))
+	require.Same(t, daily, cache.MustParse("  0  0\t* * *\n"))
+	require.Equal(t, 1, cache.Len())
+
+	// Malformed expressions are cached along with their error
+	_, err := cache.Parse("0 0 * *")
+	require.EqualError(t, err, "missing field(s)")
+	_, err = cache.Parse("0 0  * *")
+	require.EqualError(t, err, "missing field(s)")
+	require.Equal(t, 2, cache.Len())
+
+	// The least recently used expression is evicted
+	cache.MustParse("0 0 * * *")
+	hourly := cache.MustParse("@hourly")
+	require.Equal(t, 2, cache.Len())
+	require.Same(t, daily, cache.MustParse("0 0 * * *"))
+	require.Same(t, hourly, cache.MustParse("@hourly"))
+
+	cache.MustParse("0 12 * * *")
+	require.NotSame(t, daily, cache.MustParse("0 0 * * *"))
+	require.Equal(t, 2, cache.Len())
+
+	require.Panics(t, func() { NewCache(0) })
+}
+
+func TestCache_Options(t *testing.T) {
+	cache := NewCache(10, WithLocaleNames(French), Strict(time.Time{}))
+	expr, err := cache.Parse("0 0 * * lundi")
+	require.NoError(t, err)
+	require.True(t, Equal(MustParse("0 0 * * 1"), expr))
+
+	_, err = cache.Parse("0 0 30 2 *")
+	require.True(t, errors.Is(err, ErrUnsatisfiable))
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	cache := NewCache(8)
+	var wg sync.WaitGroup
+	exprs := make([][]*Expression, 8)
+	for i := range exprs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				exprs[i] = append(exprs[i], cache.MustParse(fmt.Sprintf("%d 0 * * *", j%4)))
+			}
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(t, 4, cache.Len())
+	// All goroutines share the same Expression for each spelling
+	for i := range exprs {
+		for j := range exprs[i] {
+			require.Same(t, exprs[0][j%4], exprs[i][j])
+		}
+	}
+}
+
+/******************************************************************************/
+
+func BenchmarkCache_Parse(b *testing.B) {
+	cache := NewCache(len(benchmarkExpressions))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cache.MustParse(benchmarkExpressions[i%len(benchmarkExpressions)])
+	}
+}
@@ -0,0 +1,304 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_collision.go
+ * Version: 1.0
+ * License: pick the one which suits you :
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"sort"
+	"time"
+)
+
+/******************************************************************************/
+
+// MaxCollisionInstants is the number of colliding time instants recorded in
+// Collision.First.
+const MaxCollisionInstants = 10
+
+// A Collision reports how often two expressions of a set fire at the same
+// time, or close to each other.
+type Collision struct {
+	// A and B are the indices of the colliding expressions, A < B.
+	A, B int
+	// First holds, in chronological ascending order, the first time instants
+	// at which expression A fires close to expression B, up to
+	// MaxCollisionInstants of them.
+	First []time.Time
+	// Count is the number of pairs of time instants, one from each
+	// expression, which are close to each other.
+	Count int
+}
+
+/******************************************************************************/
+
+// Collisions returns, for each pair of expressions of `exprs` which collide
+// between `fromTime` (excluded) and `fromTime + horizon` (included), how
+// they collide. Two time instants collide when they are no more than
+// `window` apart, so a zero `window` reports the time instants at which both
+// expressions fire at once.
+//
+// The time instants are computed in the `time.Location` of `fromTime`.
+//
+// Identical time instants are found by intersecting the fields of both
+// expressions, one day at a time, so that the cost does not depend on how
+// often they fire. Time instants which are less than a day apart are counted
+// from the times of day of both expressions, one day at a time as well. Only
+// a `window` of a day or more walks through the time instants of both
+// expressions.
+func Collisions(exprs []*Expression, fromTime time.Time, horizon, window time.Duration) []Collision {
+	toTime := fromTime.Add(horizon)
+	collisions := []Collision{}
+	for a := 0; a < len(exprs); a++ {
+		for b := a + 1; b < len(exprs); b++ {
+			c := Collision{A: a, B: b}
+			switch {
+			case window < time.Second:
+				c.intersect(exprs[a], exprs[b], fromTime, toTime)
+			case window < 24*time.Hour:
+				c.merge(exprs[a], exprs[b], fromTime, toTime, window)
+			default:
+				c.walk(exprs[a], exprs[b], fromTime, toTime, window)
+			}
+			if c.Count > 0 {
+				collisions = append(collisions, c)
+			}
+		}
+	}
+	return collisions
+}
+
+/******************************************************************************/
+
+func (c *Collision) add(t time.Time, n int) {
+	c.Count += n
+	if len(c.First) < MaxCollisionInstants {
+		c.First = append(c.First, t)
+	}
+}
+
+// full reports whether the first colliding time instants are all recorded,
+// so that the following ones need only be counted.
+func (c *Collision) full() bool {
+	return len(c.First) == MaxCollisionInstants
+}
+
+// intersect records the time instants at which both `a` and `b` fire.
+func (c *Collision) intersect(a, b *Expression, fromTime, toTime time.Time) {
+	seconds := (a.seconds & b.seconds).list()
+	minutes := (a.minutes & b.minutes).list()
+	hours := (a.hours & b.hours).list()
+	if len(seconds) == 0 || len(minutes) == 0 || len(hours) == 0 {
+		return
+	}
+	perDay := len(hours) * len(minutes) * len(seconds)
+
+	forEachDay(fromTime, toTime, func(year, month int) bitset {
+		return a.datesIn(year, month) & b.datesIn(year, month)
+	}, func(dayBegin, dayEnd time.Time) {
+		// A whole day without daylight saving transition, whose time
+		// instants need not be listed: count them all at once
+		if c.full() && dayBegin.After(fromTime) && !dayEnd.After(toTime) && !timeZoneInDay(dayBegin) {
+			c.Count += perDay
+			return
+		}
+		for _, t := range dayInstants(dayBegin, hours, minutes, seconds) {
+			if t.After(fromTime) && !t.After(toTime) {
+				c.add(t, 1)
+			}
+		}
+	})
+}
+
+// merge records the time instants at which `a` fires less than a day apart
+// from `b`. A time instant of `a` can only be close to time instants of `b`
+// on the same day or on the days before and after it, so that, away from
+// daylight saving transitions, the collisions of a day only depend on which
+// of these three days `b` fires on.
+func (c *Collision) merge(a, b *Expression, fromTime, toTime time.Time, window time.Duration) {
+	aTimes, bTimes := a.timesOfDay(), b.timesOfDay()
+	w := int(window / time.Second)
+	// nearCount counts the times of day of `b` no more than `w` seconds
+	// apart from time of day `x` of `a`, `b` firing on the days before, on
+	// and after that of `x` as per `days`
+	nearCount := func(x int, days [3]bool) int {
+		n := 0
+		for i, shift := range []int{-secondsPerDay, 0, secondsPerDay} {
+			if days[i] {
+				n += sort.SearchInts(bTimes, x-shift+w+1) - sort.SearchInts(bTimes, x-shift-w)
+			}
+		}
+		return n
+	}
+	perDay := map[[3]bool]int{}
+
+	forEachDay(fromTime, toTime, a.datesIn, func(dayBegin, dayEnd time.Time) {
+		year, month, dom := dayBegin.Date()
+		days := [3]bool{
+			b.firesOn(year, month, dom-1),
+			b.firesOn(year, month, dom),
+			b.firesOn(year, month, dom+1),
+		}
+		if days == [3]bool{} {
+			return
+		}
+		if c.full() && dayBegin.Add(-window).After(fromTime) && !dayEnd.Add(window).After(toTime) &&
+			!timeZoneInDay(dayBegin) && !timeZoneInDay(dayEnd) {
+			n, ok := perDay[days]
+			if !ok {
+				for _, x := range aTimes {
+					n += nearCount(x, days)
+				}
+				perDay[days] = n
+			}
+			c.Count += n
+			return
+		}
+		// Time instants of `b` on the days before, on and after this day, in
+		// chronological order
+		near := []time.Time{}
+		for i, day := range []time.Time{dayBegin.AddDate(0, 0, -1), dayBegin, dayEnd} {
+			if !days[i] {
+				continue
+			}
+			for _, tb := range dayInstants(day, b.hourList, b.minuteList, b.secondList) {
+				if tb.After(fromTime) && !tb.After(toTime) {
+					near = append(near, tb)
+				}
+			}
+		}
+		// near[lo:hi] are the time instants of `b` within `window` of `ta`
+		lo, hi := 0, 0
+		for _, ta := range dayInstants(dayBegin, a.hourList, a.minuteList, a.secondList) {
+			if !ta.After(fromTime) || ta.After(toTime) {
+				continue
+			}
+			for hi < len(near) && near[hi].Sub(ta) <= window {
+				hi++
+			}
+			for lo < hi && ta.Sub(near[lo]) > window {
+				lo++
+			}
+			if hi > lo {
+				c.add(ta, hi-lo)
+			}
+		}
+	})
+}
+
+// walk records the time instants at which `a` fires no more than `window`
+// apart from `b`, by walking through the time instants of both expressions.
+func (c *Collision) walk(a, b *Expression, fromTime, toTime time.Time, window time.Duration) {
+	// Time instants of `b` within `window` of the current time instant of `a`
+	var near []time.Time
+	nextB := b.Next(fromTime)
+	for ta := a.Next(fromTime); !ta.IsZero() && !ta.After(toTime); ta = a.Next(ta) {
+		for !nextB.IsZero() && !nextB.After(toTime) && nextB.Sub(ta) <= window {
+			near = append(near, nextB)
+			nextB = b.Next(nextB)
+		}
+		for len(near) > 0 && ta.Sub(near[0]) > window {
+			near = near[1:]
+		}
+		if len(near) > 0 {
+			c.add(ta, len(near))
+		}
+	}
+}
+
+/******************************************************************************/
+
+const secondsPerDay = 24 * 60 * 60
+
+// forEachDay calls `visit` with the beginning and end of each day matched by
+// `dates` which overlaps `fromTime` (excluded) to `toTime` (included), in
+// chronological order.
+func forEachDay(fromTime, toTime time.Time, dates func(year, month int) bitset, visit func(dayBegin, dayEnd time.Time)) {
+	if !fromTime.Before(toTime) {
+		return
+	}
+	loc := fromTime.Location()
+	year, month, _ := fromTime.Date()
+	last := time.Date(toTime.Year(), toTime.Month(), 1, 0, 0, 0, 0, loc)
+	for first := time.Date(year, month, 1, 0, 0, 0, 0, loc); !first.After(last); first = first.AddDate(0, 1, 0) {
+		year, month := first.Year(), first.Month()
+		for _, dom := range dates(year, int(month)).list() {
+			dayBegin := time.Date(year, month, dom, 0, 0, 0, 0, loc)
+			dayEnd := time.Date(year, month, dom+1, 0, 0, 0, 0, loc)
+			if dayEnd.After(fromTime) && !dayBegin.After(toTime) {
+				visit(dayBegin, dayEnd)
+			}
+		}
+	}
+}
+
+// firesOn reports whether the expression fires on the given day, which is
+// normalized as time.Date does.
+func (expr *Expression) firesOn(year int, month time.Month, dom int) bool {
+	day := time.Date(year, month, dom, 0, 0, 0, 0, time.UTC)
+	return expr.datesIn(day.Year(), int(day.Month())).has(day.Day())
+}
+
+// timesOfDay returns the matching times of day of the expression, as sorted
+// numbers of seconds since midnight.
+func (expr *Expression) timesOfDay() []int {
+	times := make([]int, 0, len(expr.hourList)*len(expr.minuteList)*len(expr.secondList))
+	for _, hour := range expr.hourList {
+		for _, minute := range expr.minuteList {
+			for _, second := range expr.secondList {
+				times = append(times, hour*3600+minute*60+second)
+			}
+		}
+	}
+	return times
+}
+
+// dayInstants returns, in chronological order, the time instants of the day
+// beginning at `dayBegin` whose wall clock matches `hours`, `minutes` and
+// `seconds`, as Next does: wall clock times skipped when clocks spring
+// forward do not match, and those repeated when clocks fall back match twice.
+func dayInstants(dayBegin time.Time, hours, minutes, seconds []int) []time.Time {
+	year, month, dom := dayBegin.Date()
+	loc := dayBegin.Location()
+	// Clocks falling back during the day repeat `repeat` of wall clock time
+	var repeat time.Duration
+	if timeZoneInDay(dayBegin) {
+		_, beginOffset := dayBegin.Zone()
+		_, endOffset := time.Date(year, month, dom+1, 0, 0, 0, 0, loc).Zone()
+		repeat = time.Duration(beginOffset-endOffset) * time.Second
+	}
+	instants := make([]time.Time, 0, len(hours)*len(minutes)*len(seconds))
+	for _, hour := range hours {
+		for _, minute := range minutes {
+			for _, second := range seconds {
+				t := time.Date(year, month, dom, hour, minute, second, 0, loc)
+				// Skip wall clock times which do not exist
+				if t.Hour() != hour || t.Minute() != minute {
+					continue
+				}
+				instants = append(instants, t)
+				if repeat <= 0 {
+					continue
+				}
+				for _, other := range []time.Time{t.Add(-repeat), t.Add(repeat)} {
+					if other.Day() == dom && other.Hour() == hour && other.Minute() == minute && other.Second() == second {
+						instants = append(instants, other)
+					}
+				}
+			}
+		}
+	}
+	if repeat > 0 {
+		sort.Slice(instants, func(i, j int) bool { return instants[i].Before(instants[j]) })
+	}
+	return instants
+}
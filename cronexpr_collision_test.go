@@ -0,0 +1,129 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_collision_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+/******************************************************************************/
+
+func TestCollisions(t *testing.T) {
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	exprs := []*Expression{
+		MustParse("0 2 * * *"),
+		MustParse("0 */6 * * 1"),
+		MustParse("55 1 * * *"),
+		MustParse("0 3 * * *"),
+	}
+
+	collisions := Collisions(exprs, from, 7*24*time.Hour, 0)
+	require.Len(t, collisions, 0)
+
+	collisions = Collisions(exprs, from, 7*24*time.Hour, 5*time.Minute)
+	require.Len(t, collisions, 1)
+	require.Equal(t, 0, collisions[0].A)
+	require.Equal(t, 2, collisions[0].B)
+	require.Equal(t, 7, collisions[0].Count)
+	require.Equal(t, time.Date(2024, time.January, 1, 2, 0, 0, 0, time.UTC), collisions[0].First[0])
+
+	collisions = Collisions(exprs, from, 7*24*time.Hour, time.Hour)
+	require.Len(t, collisions, 2)
+	require.Equal(t, 0, collisions[1].A)
+	require.Equal(t, 3, collisions[1].B)
+
+	// Every Monday at 06:00, and the first ten of them are recorded
+	exprs = append(exprs, MustParse("0 6 * * *"))
+	collisions = Collisions(exprs, from, 365*24*time.Hour, 0)
+	require.Len(t, collisions, 1)
+	require.Equal(t, 1, collisions[0].A)
+	require.Equal(t, 4, collisions[0].B)
+	require.Equal(t, 53, collisions[0].Count)
+	require.Len(t, collisions[0].First, MaxCollisionInstants)
+	require.Equal(t, time.Date(2024, time.March, 4, 6, 0, 0, 0, time.UTC), collisions[0].First[9])
+}
+
+var collisionPairs = [][2]string{
+	{"*/15 * * * *", "*/10 * * * *"},
+	{"0 9 * * 1-5", "0 9 1 * *"},
+	{"0 0 * * 5L", "0 0 L * *"},
+	{"0 0 15W * *", "0 0 * * 1"},
+	{"30 2 * * *", "30 */2 * * *"},
+	{"30 1 * * *", "30 1 * * *"},
+	{"0 0 29 2 *", "0 0 * * 4"},
+	{"*/5 * 1 * * * *", "*/3 * 1 * * * *"},
+	{"0 1 * * *", "0 2 * * *"},
+	{"55 23 * * 5", "5 0 * * 6"},
+}
+
+// forEachCollisionPair calls `check` for each pair of collisionPairs, over
+// three months which include a daylight saving transition in New York.
+func forEachCollisionPair(t *testing.T, check func(a, b *Expression, fromTime, toTime time.Time, msg string)) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	for _, loc := range []*time.Location{time.UTC, newYork} {
+		for _, month := range []time.Month{time.January, time.October} {
+			from := time.Date(2024, month, 1, 10, 30, 0, 0, loc)
+			for _, pair := range collisionPairs {
+				msg := fmt.Sprintf("%s and %s from %s", pair[0], pair[1], from)
+				check(MustParse(pair[0]), MustParse(pair[1]), from, from.AddDate(0, 3, 0), msg)
+			}
+		}
+	}
+}
+
+// Identical time instants found by intersecting fields must be the same as
+// those found by walking through the time instants of both expressions.
+func TestCollisions_Intersection(t *testing.T) {
+	forEachCollisionPair(t, func(a, b *Expression, fromTime, toTime time.Time, msg string) {
+		var intersected, walked Collision
+		intersected.intersect(a, b, fromTime, toTime)
+		walked.walk(a, b, fromTime, toTime, 0)
+		require.Equal(t, walked, intersected, msg)
+	})
+}
+
+// Close time instants counted one day at a time must be the same as those
+// found by walking through the time instants of both expressions.
+func TestCollisions_Merge(t *testing.T) {
+	windows := []time.Duration{90 * time.Second, 90 * time.Minute, 23 * time.Hour}
+	forEachCollisionPair(t, func(a, b *Expression, fromTime, toTime time.Time, msg string) {
+		for _, window := range windows {
+			var merged, walked Collision
+			merged.merge(a, b, fromTime, toTime, window)
+			walked.walk(a, b, fromTime, toTime, window)
+			require.Equal(t, walked, merged, "%s within %s", msg, window)
+		}
+	})
+}
+
+// Wall clock times repeated when clocks fall back collide twice, whatever
+// the window.
+func TestCollisions_FallBack(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	from := time.Date(2024, time.November, 3, 0, 0, 0, 0, newYork)
+	exprs := []*Expression{MustParse("30 1 * * *"), MustParse("30 1 * * *")}
+	for _, window := range []time.Duration{0, time.Second, time.Minute} {
+		collisions := Collisions(exprs, from, 36*time.Hour, window)
+		require.Len(t, collisions, 1)
+		require.Equal(t, 3, collisions[0].Count, "within %s", window)
+		require.Equal(t, time.Hour, collisions[0].First[1].Sub(collisions[0].First[0]))
+	}
+}
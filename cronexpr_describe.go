@@ -0,0 +1,367 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_describe.go
+ * Version: 1.0
+ * License: pick the one which suits you :
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+/******************************************************************************/
+
+// Describe returns a human-readable English description of the cron
+// expression `expr`, such as "At 12:00, on the weekday nearest day 15 of the
+// month, every 3 months starting in March" for `0 12 15W 3/3 *`.
+func Describe(expr *Expression) string {
+	return DescribeIn(expr, English)
+}
+
+// DescribeIn returns a human-readable description of the cron expression
+// `expr` in the language of `locale`.
+func DescribeIn(expr *Expression, locale Locale) string {
+	d := describer{locale: locale}
+
+	macro := strings.TrimSpace(expr.expression)
+	if strings.HasPrefix(macro, "@") {
+		if description := d.message(macro); description != "" {
+			return description
+		}
+	}
+
+	// Describing the fields of an expression which never fires would read as
+	// if it fired, i.e. "At 00:00" for `0 0 30 2 *`
+	if !expr.satisfiable {
+		return d.message("never")
+	}
+
+	phrases := []string{d.describeTime(expr)}
+	if phrase := d.describeDays(expr); phrase != "" {
+		phrases = append(phrases, phrase)
+	}
+	if phrase := d.describeMonths(expr.monthList); phrase != "" {
+		phrases = append(phrases, phrase)
+	}
+	if phrase := d.describeYears(expr.yearList); phrase != "" {
+		phrases = append(phrases, phrase)
+	}
+	description := strings.Join(phrases, ", ")
+	first, size := utf8.DecodeRuneInString(description)
+	return string(unicode.ToUpper(first)) + description[size:]
+}
+
+/******************************************************************************/
+
+const (
+	shapeList = iota
+	shapeAll
+	shapeOne
+	shapeRange
+	shapeInterval
+	shapeSteppedRange
+)
+
+// A fieldShape tells how the sorted values of a field can be summarized,
+// e.g. `[0 15 30 45]` as "every 15 starting at 0".
+type fieldShape struct {
+	kind  int
+	first int
+	last  int
+	step  int
+}
+
+func shapeOf(list []int, min, max int) fieldShape {
+	n := len(list)
+	shape := fieldShape{kind: shapeList}
+	if n == 0 {
+		return shape
+	}
+	shape.first, shape.last = list[0], list[n-1]
+	if n == max-min+1 {
+		shape.kind = shapeAll
+		return shape
+	}
+	if n == 1 {
+		shape.kind = shapeOne
+		return shape
+	}
+	shape.step = list[1] - list[0]
+	for i := 2; i < n; i++ {
+		if list[i]-list[i-1] != shape.step {
+			return shape
+		}
+	}
+	switch {
+	case shape.step == 1 && n >= 3:
+		shape.kind = shapeRange
+	case shape.step > 1 && shape.last+shape.step > max && (n >= 3 || (shape.first == min && (max-min+1)%shape.step == 0)):
+		shape.kind = shapeInterval
+	case shape.step > 1 && n >= 3:
+		shape.kind = shapeSteppedRange
+	}
+	return shape
+}
+
+/******************************************************************************/
+
+// A describer assembles the phrases of a description from the messages of
+// its locale, falling back to English for messages the locale lacks.
+type describer struct {
+	locale Locale
+}
+
+func (d describer) message(key string) string {
+	if message := d.locale.Message(key); message != "" {
+		return message
+	}
+	return English.Message(key)
+}
+
+func (d describer) sprintf(key string, args ...interface{}) string {
+	return fmt.Sprintf(d.message(key), args...)
+}
+
+// join joins `items` as in "a, b and c".
+func (d describer) join(items []string, conjunction string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " " + d.message(conjunction) + " " + items[len(items)-1]
+}
+
+/******************************************************************************/
+
+func (d describer) describeTime(expr *Expression) string {
+	seconds := shapeOf(expr.secondList, secondDescriptor.min, secondDescriptor.max)
+	minutes := shapeOf(expr.minuteList, minuteDescriptor.min, minuteDescriptor.max)
+	hours := shapeOf(expr.hourList, hourDescriptor.min, hourDescriptor.max)
+	onTheMinute := seconds.kind == shapeOne && seconds.first == 0
+
+	// `30 9,17 * * *`: at 09:30 and 17:30
+	if seconds.kind == shapeOne && minutes.kind == shapeOne && (hours.kind == shapeOne || hours.kind == shapeList) {
+		times := make([]string, len(expr.hourList))
+		for i, hour := range expr.hourList {
+			times[i] = clockTime(hour, minutes.first, seconds.first)
+		}
+		return d.sprintf("time.at", d.join(times, "and"))
+	}
+
+	// `0 */2 * * *`: every 2 hours
+	if onTheMinute && minutes.kind == shapeOne && minutes.first == 0 {
+		switch hours.kind {
+		case shapeAll:
+			return d.message("hour.every")
+		case shapeRange:
+			return d.sprintf("hour.everyFromThrough", clockTime(hours.first, 0, 0), clockTime(hours.last, 0, 0))
+		case shapeInterval:
+			if hours.first == hourDescriptor.min {
+				return d.sprintf("hour.everyN", hours.step)
+			}
+			return d.sprintf("hour.everyNStartingAt", hours.step, clockTime(hours.first, 0, 0))
+		case shapeSteppedRange:
+			return d.sprintf("hour.everyNFromThrough", hours.step, clockTime(hours.first, 0, 0), clockTime(hours.last, 0, 0))
+		}
+	}
+
+	var phrases []string
+	if !onTheMinute {
+		phrases = append(phrases, d.describeUnits("second", seconds, expr.secondList))
+	}
+	if onTheMinute || minutes.kind != shapeAll {
+		phrases = append(phrases, d.describeUnits("minute", minutes, expr.minuteList))
+	}
+	if phrase := d.describeHours(hours, expr.hourList); phrase != "" {
+		phrases = append(phrases, phrase)
+	}
+	return strings.Join(phrases, ", ")
+}
+
+// describeUnits describes the seconds of a minute or the minutes of an hour.
+func (d describer) describeUnits(unit string, shape fieldShape, list []int) string {
+	switch shape.kind {
+	case shapeAll:
+		return d.message(unit + ".every")
+	case shapeOne:
+		if shape.first == 1 {
+			return d.sprintf(unit+".atOne", shape.first)
+		}
+		return d.sprintf(unit+".at", shape.first)
+	case shapeRange:
+		return d.sprintf(unit+".everyBetween", shape.first, shape.last)
+	case shapeInterval:
+		if shape.first == 0 {
+			return d.sprintf(unit+".everyN", shape.step)
+		}
+		return d.sprintf(unit+".everyNStartingAt", shape.step, shape.first)
+	case shapeSteppedRange:
+		return d.sprintf(unit+".everyNBetween", shape.step, shape.first, shape.last)
+	}
+	return d.sprintf(unit+".atList", d.join(itoaList(list), "and"))
+}
+
+func (d describer) describeHours(shape fieldShape, list []int) string {
+	switch shape.kind {
+	case shapeAll:
+		return ""
+	case shapeOne, shapeRange:
+		return d.sprintf("hour.between", clockTime(shape.first, 0, 0), clockTime(shape.last, 59, 0))
+	case shapeInterval:
+		if shape.first == hourDescriptor.min {
+			return d.sprintf("hour.everyN", shape.step)
+		}
+		return d.sprintf("hour.everyNStartingAt", shape.step, clockTime(shape.first, 0, 0))
+	case shapeSteppedRange:
+		return d.sprintf("hour.everyNBetween", shape.step, clockTime(shape.first, 0, 0), clockTime(shape.last, 59, 0))
+	}
+	hours := make([]string, len(list))
+	for i, hour := range list {
+		hours[i] = clockTime(hour, 0, 0)
+	}
+	return d.sprintf("hour.during", d.join(hours, "and"))
+}
+
+/******************************************************************************/
+
+func (d describer) describeDays(expr *Expression) string {
+	var phrases []string
+
+	// day-of-month != `*`
+	if expr.daysOfMonthRestricted {
+		if expr.daysOfMonth != 0 {
+			phrases = append(phrases, d.describeDaysOfMonth(expr.daysOfMonth.list()))
+		}
+		for _, v := range expr.workdaysOfMonth.list() {
+			phrases = append(phrases, d.sprintf("dom.workday", v))
+		}
+		if expr.lastDayOfMonth {
+			phrases = append(phrases, d.message("dom.last"))
+		}
+		if expr.lastWorkdayOfMonth {
+			phrases = append(phrases, d.message("dom.lastWorkday"))
+		}
+	}
+
+	// day-of-week != `*`
+	if expr.daysOfWeekRestricted {
+		if expr.daysOfWeek != 0 {
+			phrases = append(phrases, d.describeDaysOfWeek(expr.daysOfWeek.list()))
+		}
+		for _, v := range expr.specificWeekDaysOfWeek.list() {
+			phrases = append(phrases, d.sprintf("dow.specific", d.locale.WeekOrdinal(v/7+1), d.locale.DayOfWeekName(v%7)))
+		}
+		if expr.lastWeekDaysOfWeek != 0 {
+			phrases = append(phrases, d.sprintf("dow.last", d.join(d.dowNameList(expr.lastWeekDaysOfWeek.list()), "and")))
+		}
+	}
+
+	// As per crontab man page, a day matches when either field matches
+	return d.join(phrases, "or")
+}
+
+func (d describer) describeDaysOfMonth(list []int) string {
+	shape := shapeOf(list, domDescriptor.min, domDescriptor.max)
+	switch shape.kind {
+	case shapeOne:
+		return d.sprintf("dom.one", shape.first)
+	case shapeAll, shapeRange:
+		return d.sprintf("dom.fromThrough", shape.first, shape.last)
+	case shapeInterval:
+		return d.sprintf("dom.everyNStartingOn", shape.step, shape.first)
+	case shapeSteppedRange:
+		return d.sprintf("dom.everyNFromThrough", shape.step, shape.first, shape.last)
+	}
+	return d.sprintf("dom.list", d.join(itoaList(list), "and"))
+}
+
+func (d describer) describeDaysOfWeek(list []int) string {
+	shape := shapeOf(list, dowDescriptor.min, dowDescriptor.max)
+	switch shape.kind {
+	case shapeAll, shapeRange:
+		return d.sprintf("dow.fromThrough", d.locale.DayOfWeekName(shape.first), d.locale.DayOfWeekName(shape.last))
+	}
+	return d.sprintf("dow.list", d.join(d.dowNameList(list), "and"))
+}
+
+/******************************************************************************/
+
+func (d describer) describeMonths(list []int) string {
+	shape := shapeOf(list, monthDescriptor.min, monthDescriptor.max)
+	switch shape.kind {
+	case shapeAll:
+		return ""
+	case shapeOne:
+		return d.sprintf("month.one", d.locale.MonthName(shape.first))
+	case shapeRange:
+		return d.sprintf("month.fromThrough", d.locale.MonthName(shape.first), d.locale.MonthName(shape.last))
+	case shapeInterval:
+		if shape.first == monthDescriptor.min {
+			return d.sprintf("month.everyN", shape.step)
+		}
+		return d.sprintf("month.everyNStartingIn", shape.step, d.locale.MonthName(shape.first))
+	case shapeSteppedRange:
+		return d.sprintf("month.everyNFromThrough", shape.step, d.locale.MonthName(shape.first), d.locale.MonthName(shape.last))
+	}
+	names := make([]string, len(list))
+	for i, v := range list {
+		names[i] = d.locale.MonthName(v)
+	}
+	return d.sprintf("month.list", d.join(names, "and"))
+}
+
+func (d describer) describeYears(list []int) string {
+	shape := shapeOf(list, yearDescriptor.min, yearDescriptor.max)
+	switch shape.kind {
+	case shapeAll:
+		return ""
+	case shapeOne:
+		return d.sprintf("year.one", shape.first)
+	case shapeRange:
+		return d.sprintf("year.fromThrough", shape.first, shape.last)
+	case shapeInterval:
+		return d.sprintf("year.everyNStartingIn", shape.step, shape.first)
+	case shapeSteppedRange:
+		return d.sprintf("year.everyNFromThrough", shape.step, shape.first, shape.last)
+	}
+	return d.sprintf("year.list", d.join(itoaList(list), "and"))
+}
+
+/******************************************************************************/
+
+func (d describer) dowNameList(list []int) []string {
+	names := make([]string, len(list))
+	for i, v := range list {
+		names[i] = d.locale.DayOfWeekName(v)
+	}
+	return names
+}
+
+func clockTime(hour, minute, second int) string {
+	if second != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", hour, minute, second)
+	}
+	return fmt.Sprintf("%02d:%02d", hour, minute)
+}
+
+func itoaList(list []int) []string {
+	items := make([]string, len(list))
+	for i, v := range list {
+		items[i] = fmt.Sprint(v)
+	}
+	return items
+}
@@ -0,0 +1,131 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_describe_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+/******************************************************************************/
+
+var describeTests = []struct {
+	expr        string
+	description string
+}{
+	// Time of day
+	{"* * * * *", "Every minute"},
+	{"*/15 * * * *", "Every 15 minutes"},
+	{"0,45 * * * *", "At 0 and 45 minutes past the hour"},
+	{"1 * * * *", "At 1 minute past the hour"},
+	{"5-10 * * * *", "Every minute between 5 and 10 minutes past the hour"},
+	{"0 * * * *", "Every hour"},
+	{"0 */2 * * *", "Every 2 hours"},
+	{"0 9-17 * * *", "Every hour from 09:00 through 17:00"},
+	{"0 10-20/5 * * *", "Every 5 hours starting at 10:00"},
+	{"30 */2 * * *", "At 30 minutes past the hour, every 2 hours"},
+	{"*/5 9-17 * * *", "Every 5 minutes, between 09:00 and 17:59"},
+	{"*/15 9,17 * * *", "Every 15 minutes, during the 09:00 and 17:00 hours"},
+	{"30 9,17 * * *", "At 09:30 and 17:30"},
+	{"* * * * * * *", "Every second"},
+	{"*/10 * * * * * *", "Every 10 seconds"},
+
+	// Days of month
+	{"0 0 1,15 * *", "At 00:00, on days 1 and 15 of the month"},
+	{"0 0 1-15 * *", "At 00:00, on days 1 through 15 of the month"},
+	{"0 0 */5 * *", "At 00:00, every 5 days starting on day 1 of the month"},
+	{"0 0 L * *", "At 00:00, on the last day of the month"},
+	{"0 0 LW * *", "At 00:00, on the last weekday of the month"},
+	{"0 12 15W 3/3 *", "At 12:00, on the weekday nearest day 15 of the month, every 3 months starting in March"},
+
+	// Days of week
+	{"0 9 * * 1-5", "At 09:00, on Monday through Friday"},
+	{"0 0 * * 1,3,5", "At 00:00, on Monday, Wednesday and Friday"},
+	{"0 0 * * 6#5", "At 00:00, on the fifth Saturday of the month"},
+	{"0 17 * * 5L", "At 17:00, on the last Friday of the month"},
+	{"0 0 * * 1L,5L", "At 00:00, on the last Monday and Friday of the month"},
+	{"0 0 1,L * 1", "At 00:00, on day 1 of the month, on the last day of the month or on Monday"},
+
+	// Months and years
+	{"15 10 * 1,6,12 *", "At 10:15, only in January, June and December"},
+	{"15 10 * 3-6 *", "At 10:15, from March through June"},
+	{"15 10 * * * 2020", "At 10:15, only in 2020"},
+	{"0 0 1 1 * 2020/4", "At 00:00, on day 1 of the month, only in January, every 4 years starting in 2020"},
+	{"30 0 0 1-31/5 Oct-Dec * 2000,2006,2008,2013-2015", "At 00:00:30, every 5 days starting on day 1 of the month, from October through December, only in 2000, 2006, 2008, 2013, 2014 and 2015"},
+	{"0 0 0 * Feb-Nov/2 thu#3 2000-2050", "At 00:00, on the third Thursday of the month, every 2 months from February through October, from 2000 through 2050"},
+
+	// Expressions which never fire
+	{"0 0 30 2 *", "Never, as no time instant matches"},
+	{"0 0 5-3 * *", "Never, as no time instant matches"},
+	{"5-3 * * * *", "Never, as no time instant matches"},
+
+	// Predefined cron expressions
+	{"@yearly", "Once a year, at 00:00 on January 1"},
+	{"@weekly", "Once a week, at 00:00 on Sunday"},
+	{"@hourly", "Once an hour, at the beginning of the hour"},
+}
+
+func TestDescribe(t *testing.T) {
+	for _, test := range describeTests {
+		description := Describe(MustParse(test.expr))
+		if description != test.description {
+			t.Errorf(`Describe("%s") = "%s", expected "%s"`, test.expr, description, test.description)
+		}
+	}
+}
+
+/******************************************************************************/
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+func TestDescribeIn_Golden(t *testing.T) {
+	for name, locale := range map[string]Locale{"en": English, "de": German, "fr": French} {
+		t.Run(name, func(t *testing.T) {
+			var b strings.Builder
+			for _, test := range describeTests {
+				fmt.Fprintf(&b, "%s\t%s\n", test.expr, DescribeIn(MustParse(test.expr), locale))
+			}
+			golden := filepath.Join("testdata", "describe."+name+".golden")
+			if *updateGolden {
+				require.NoError(t, os.WriteFile(golden, []byte(b.String()), 0644))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			require.Equal(t, string(expected), b.String())
+		})
+	}
+}
+
+func TestCatalogs(t *testing.T) {
+	for name, catalog := range map[string]*Catalog{"de": German, "fr": French} {
+		for key := range English.Messages {
+			require.Contains(t, catalog.Messages, key, "%s catalog", name)
+		}
+	}
+
+	// English names match the tokens accepted by the parser
+	for month := 1; month <= 12; month++ {
+		require.Equal(t, month, monthTokens[strings.ToLower(English.MonthName(month))])
+	}
+	for dow := 0; dow <= 6; dow++ {
+		require.Equal(t, dow, dowTokens[strings.ToLower(English.DayOfWeekName(dow))])
+	}
+}
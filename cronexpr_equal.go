@@ -0,0 +1,94 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_equal.go
+ * Version: 1.0
+ * License: pick the one which suits you :
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"reflect"
+	"sort"
+)
+
+/******************************************************************************/
+
+// Equal reports whether the cron expressions `a` and `b` match exactly the
+// same time instants, regardless of how they are spelled. For instance
+// `*/15 * * * *` equals `0,15,30,45 * * * *`, and `@weekly` equals
+// `0 0 * * 0`.
+//
+// Fields are compared as normalized sets of values. Where the matching days
+// depend on the calendar (`L`, `W`, `#`, or days of month and days of week
+// both being restricted), the days matched by both expressions are compared
+// month by month over the whole range of years supported by the parser.
+func Equal(a, b *Expression) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	// Expressions which never match are all equal, whatever their fields
+	if !a.satisfiable || !b.satisfiable {
+		return a.satisfiable == b.satisfiable
+	}
+	if sameDays(a, b) {
+		return sameTimes(a, b)
+	}
+	for year := yearDescriptor.min; year <= yearDescriptor.max; year++ {
+		for month := 1; month <= 12; month++ {
+			if a.datesIn(year, month) != b.datesIn(year, month) {
+				return false
+			}
+		}
+	}
+	return sameTimes(a, b)
+}
+
+/******************************************************************************/
+
+// datesIn returns the days of `month` in `year` matched by the expression.
+func (expr *Expression) datesIn(year, month int) bitset {
+	if i := sort.SearchInts(expr.yearList, year); i == len(expr.yearList) || expr.yearList[i] != year || !expr.months.has(month) {
+		return 0
+	}
+	return expr.actualDaysOfMonth(year, month)
+}
+
+func sameTimes(a, b *Expression) bool {
+	return equalInts(a.secondList, b.secondList) &&
+		equalInts(a.minuteList, b.minuteList) &&
+		equalInts(a.hourList, b.hourList)
+}
+
+// sameDays reports whether the day, month and year fields of `a` and `b` are
+// identical sets, in which case they match the same days in every month.
+func sameDays(a, b *Expression) bool {
+	return equalInts(a.yearList, b.yearList) &&
+		equalInts(a.monthList, b.monthList) &&
+		a.daysOfMonthRestricted == b.daysOfMonthRestricted &&
+		a.daysOfWeekRestricted == b.daysOfWeekRestricted &&
+		a.lastDayOfMonth == b.lastDayOfMonth &&
+		a.lastWorkdayOfMonth == b.lastWorkdayOfMonth &&
+		a.daysOfMonth == b.daysOfMonth &&
+		a.workdaysOfMonth == b.workdaysOfMonth &&
+		a.daysOfWeek == b.daysOfWeek &&
+		a.specificWeekDaysOfWeek == b.specificWeekDaysOfWeek &&
+		a.lastWeekDaysOfWeek == b.lastWeekDaysOfWeek
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	return len(a) == 0 || reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,74 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_equal_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"testing"
+)
+
+/******************************************************************************/
+
+var equalTests = []struct {
+	a, b  string
+	equal bool
+}{
+	// Same field sets
+	{"0 */15 * * * * *", "0 0,15,30,45 * * * * *", true},
+	{"0 */15 * * *", "0 0,15 * * *", true},
+	{"*/15 * * * *", "0,15,30,45 * * * *", true},
+	{"@weekly", "0 0 * * 0", true},
+	{"@weekly", "0 0 * * 7", true},
+	{"@yearly", "0 0 1 jan *", true},
+	{"0 9 * * mon-fri", "0 0 9 * * 1,2,3,4,5 *", true},
+	{"0 9 * * 1-5", "0 9 * * 1-6", false},
+	{"0 9 * * *", "0 0 9 * * * 1970-2099", true},
+	{"0 9 * * *", "1 9 * * *", false},
+
+	// Calendar dependent semantics
+	{"0 0 * * 0-6", "0 0 * * *", true},
+	{"0 0 1-31 * *", "0 0 * * *", true},
+	{"0 0 1-31 * 1", "0 0 * * *", true},
+	{"0 0 31 1,2 *", "0 0 31 1 *", true},
+	{"0 0 L 2 *", "0 0 28,29 2 *", false},
+	{"0 0 L 1 *", "0 0 31 1 *", true},
+	{"0 0 LW * *", "0 0 L * *", false},
+	{"0 0 1-7 * *", "0 0 * * 1#1,2#1,3#1,4#1,5#1,6#1,0#1", true},
+	{"0 0 1-7 * *", "0 0 * * 1#1,2#1,3#1,4#1,5#1,6#1", false},
+	{"0 0 * * 5#5,5L", "0 0 * * 5L", true},
+	{"0 0 * * 5#4", "0 0 * * 5L", false},
+	{"0 0 * * 5#4,5#5", "0 0 * * 5L,5#4", true},
+
+	// Expressions which never fire
+	{"0 0 30 2 *", "0 12 31 4 *", true},
+	{"0 0 30 2 *", "0 0 * * * 1980", false},
+	{"0 0 30 2 *", "5-3 * * * *", true},
+	{"0 0 30 2 *", "0 0 5-3 * *", true},
+}
+
+func TestEqual(t *testing.T) {
+	for _, test := range equalTests {
+		a, b := MustParse(test.a), MustParse(test.b)
+		if Equal(a, b) != test.equal {
+			t.Errorf(`Equal("%s", "%s") = %v, expected %v`, test.a, test.b, !test.equal, test.equal)
+		}
+		if Equal(b, a) != test.equal {
+			t.Errorf(`Equal("%s", "%s") = %v, expected %v`, test.b, test.a, !test.equal, test.equal)
+		}
+	}
+	expr := MustParse("0 0 * * *")
+	if !Equal(expr, expr) || Equal(expr, nil) {
+		t.Error("Equal() mishandles identical or nil expressions")
+	}
+}
@@ -0,0 +1,118 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_fields.go
+ * Version: 1.0
+ * License: pick the one which suits you :
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"fmt"
+)
+
+/******************************************************************************/
+
+// A Field describes one of the seven fields of a parsed expression, as
+// returned by Fields.
+type Field struct {
+	// Name is the name of the field: "second", "minute", "hour",
+	// "day-of-month", "month", "day-of-week" or "year".
+	Name string
+	// Text is the text of the field in the normalized expression, where
+	// predefined expressions such as `@daily` are expanded. It is empty for
+	// a missing optional field.
+	Text string
+	// Pos and End are the byte offsets of the field within the expression,
+	// as passed to Parse, or -1 for a missing optional field. All the fields
+	// of a predefined expression span the whole predefined expression.
+	Pos, End int
+	// Values are the values matched by the field, in ascending order, such
+	// as 0 through 6 for `*` in the day-of-week field.
+	Values []int
+	// Special holds the entries of the day-of-month and day-of-week fields
+	// whose days depend on the calendar, such as `L`, `15W` or `5#3`.
+	Special []string
+	// Restricted reports whether the field is other than `*` or `?`. When
+	// both the day-of-month and the day-of-week fields are restricted, a day
+	// matches if either of them matches.
+	Restricted bool
+}
+
+/******************************************************************************/
+
+// Fields returns the seven fields of `expr`, from the second field to the
+// year field, including the optional fields missing from the expression.
+func (expr *Expression) Fields() []Field {
+	fields := make([]Field, fieldCount)
+	lists := [fieldCount][]int{
+		secondField: expr.secondList,
+		minuteField: expr.minuteList,
+		hourField:   expr.hourList,
+		domField:    expr.daysOfMonth.list(),
+		monthField:  expr.monthList,
+		dowField:    expr.daysOfWeek.list(),
+		yearField:   expr.yearList,
+	}
+	defaults := [fieldCount][]int{
+		secondField: secondDescriptor.defaultList,
+		minuteField: minuteDescriptor.defaultList,
+		hourField:   hourDescriptor.defaultList,
+		monthField:  monthDescriptor.defaultList,
+		yearField:   yearDescriptor.defaultList,
+	}
+	for i := range fields {
+		located := expr.fields[i]
+		fields[i] = Field{
+			Name:       fieldNames[i],
+			Text:       located.text,
+			Pos:        located.beg,
+			End:        located.end,
+			Values:     append([]int{}, lists[i]...),
+			Restricted: !equalInts(lists[i], defaults[i]),
+		}
+	}
+	fields[domField].Special = expr.daysOfMonthSpecial()
+	fields[domField].Restricted = expr.daysOfMonthRestricted
+	fields[dowField].Special = expr.daysOfWeekSpecial()
+	fields[dowField].Restricted = expr.daysOfWeekRestricted
+	return fields
+}
+
+/******************************************************************************/
+
+// daysOfMonthSpecial returns the `L`, `LW` and `W` entries of the
+// day-of-month field, in their normalized spelling.
+func (expr *Expression) daysOfMonthSpecial() []string {
+	entries := []string{}
+	if expr.lastDayOfMonth {
+		entries = append(entries, "L")
+	}
+	if expr.lastWorkdayOfMonth {
+		entries = append(entries, "LW")
+	}
+	for _, v := range expr.workdaysOfMonth.list() {
+		entries = append(entries, fmt.Sprintf("%dW", v))
+	}
+	return entries
+}
+
+// daysOfWeekSpecial returns the `L` and `#` entries of the day-of-week
+// field, in their normalized spelling.
+func (expr *Expression) daysOfWeekSpecial() []string {
+	entries := []string{}
+	for _, v := range expr.lastWeekDaysOfWeek.list() {
+		entries = append(entries, fmt.Sprintf("%dL", v))
+	}
+	for _, v := range expr.specificWeekDaysOfWeek.list() {
+		entries = append(entries, fmt.Sprintf("%d#%d", v%7, v/7+1))
+	}
+	return entries
+}
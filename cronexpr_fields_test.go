@@ -0,0 +1,49 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_fields_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+/******************************************************************************/
+
+func TestFields(t *testing.T) {
+	fields := MustParse("*/20 9-11 L,15W,1 jan,jul 5#3,0").Fields()
+	require.Len(t, fields, 7)
+
+	require.Equal(t, Field{Name: "second", Pos: -1, End: -1, Values: []int{0}, Restricted: true}, fields[0])
+	require.Equal(t, Field{Name: "minute", Text: "*/20", Pos: 0, End: 4, Values: []int{0, 20, 40}, Restricted: true}, fields[1])
+	require.Equal(t, Field{Name: "hour", Text: "9-11", Pos: 5, End: 9, Values: []int{9, 10, 11}, Restricted: true}, fields[2])
+	require.Equal(t, Field{Name: "day-of-month", Text: "L,15W,1", Pos: 10, End: 17, Values: []int{1}, Special: []string{"L", "15W"}, Restricted: true}, fields[3])
+	require.Equal(t, Field{Name: "month", Text: "jan,jul", Pos: 18, End: 25, Values: []int{1, 7}, Restricted: true}, fields[4])
+	require.Equal(t, Field{Name: "day-of-week", Text: "5#3,0", Pos: 26, End: 31, Values: []int{0}, Special: []string{"5#3"}, Restricted: true}, fields[5])
+	require.Equal(t, "year", fields[6].Name)
+	require.Equal(t, "", fields[6].Text)
+	require.False(t, fields[6].Restricted)
+	require.Len(t, fields[6].Values, 130)
+
+	expr := MustParse("@weekly")
+	fields = expr.Fields()
+	require.Equal(t, Field{Name: "day-of-week", Text: "0", Pos: 0, End: 7, Values: []int{0}, Special: []string{}, Restricted: true}, fields[5])
+	require.Equal(t, Field{Name: "day-of-month", Text: "*", Pos: 0, End: 7, Values: fields[3].Values, Special: []string{}}, fields[3])
+	require.Len(t, fields[3].Values, 31)
+
+	// Values are copies, which leave the expression unchanged
+	fields[1].Values[0] = 42
+	require.Equal(t, []int{0}, expr.Fields()[1].Values)
+}
@@ -0,0 +1,82 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_intervals.go
+ * Version: 1.0
+ * License: pick the one which suits you :
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"time"
+)
+
+/******************************************************************************/
+
+// A Gap is the interval between two consecutive time instants matching an
+// expression.
+type Gap struct {
+	From, To time.Time
+}
+
+// Duration returns the elapsed time between both time instants of the gap.
+func (gap Gap) Duration() time.Duration {
+	return gap.To.Sub(gap.From)
+}
+
+// IntervalStats summarizes the gaps between consecutive time instants
+// matching an expression.
+type IntervalStats struct {
+	// Count is the number of gaps, i.e. one less than the number of matching
+	// time instants.
+	Count int
+	// Min and Max are the first shortest and the first longest gaps.
+	Min, Max Gap
+	// Mean is the average duration of a gap.
+	Mean time.Duration
+}
+
+/******************************************************************************/
+
+// Intervals returns the shortest, longest and average gaps between the
+// consecutive time instants matching `expr`, from `fromTime` (excluded) to
+// `fromTime + horizon` (included). For instance the longest gap of
+// `0 9 * * 1-5` lasts 3 days, from a Friday to the following Monday.
+//
+// Time instants are computed in the `time.Location` of `fromTime`, and gaps
+// are measured in elapsed time, so that daylight saving transitions make
+// gaps shorter or longer: with `0 */2 * * *`, a gap spanning a transition
+// lasts one or three hours.
+//
+// The zero IntervalStats is returned if fewer than two time instants match.
+func Intervals(expr *Expression, fromTime time.Time, horizon time.Duration) IntervalStats {
+	stats := IntervalStats{}
+	toTime := fromTime.Add(horizon)
+	first := expr.Next(fromTime)
+	if first.IsZero() || first.After(toTime) {
+		return stats
+	}
+	previous := first
+	for next := expr.Next(previous); !next.IsZero() && !next.After(toTime); next = expr.Next(next) {
+		gap := Gap{From: previous, To: next}
+		if stats.Count == 0 || gap.Duration() < stats.Min.Duration() {
+			stats.Min = gap
+		}
+		if stats.Count == 0 || gap.Duration() > stats.Max.Duration() {
+			stats.Max = gap
+		}
+		stats.Count += 1
+		previous = next
+	}
+	if stats.Count > 0 {
+		stats.Mean = previous.Sub(first) / time.Duration(stats.Count)
+	}
+	return stats
+}
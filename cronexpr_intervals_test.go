@@ -0,0 +1,65 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_intervals_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+/******************************************************************************/
+
+func TestIntervals(t *testing.T) {
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	stats := Intervals(MustParse("0 9 * * 1-5"), from, 14*24*time.Hour)
+	require.Equal(t, 9, stats.Count)
+	require.Equal(t, 24*time.Hour, stats.Min.Duration())
+	require.Equal(t, time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC), stats.Min.From)
+	require.Equal(t, 72*time.Hour, stats.Max.Duration())
+	require.Equal(t, time.Date(2024, time.January, 5, 9, 0, 0, 0, time.UTC), stats.Max.From)
+	require.Equal(t, time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC), stats.Max.To)
+	require.Equal(t, 11*24*time.Hour/9, stats.Mean)
+
+	stats = Intervals(MustParse("*/15 * * * *"), from, 24*time.Hour)
+	require.Equal(t, 95, stats.Count)
+	require.Equal(t, 15*time.Minute, stats.Min.Duration())
+	require.Equal(t, 15*time.Minute, stats.Max.Duration())
+	require.Equal(t, 15*time.Minute, stats.Mean)
+
+	require.Equal(t, IntervalStats{}, Intervals(MustParse("0 0 1 1 *"), from, 24*time.Hour))
+	require.Equal(t, IntervalStats{}, Intervals(MustParse("0 0 30 2 *"), from, 24*time.Hour))
+}
+
+func TestIntervals_DaylightSaving(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Clocks jump from 02:00 to 03:00 on 2024-03-10, so that 02:00 is skipped
+	from := time.Date(2024, time.March, 9, 0, 0, 0, 0, newYork)
+	stats := Intervals(MustParse("0 */2 * * *"), from, 3*24*time.Hour)
+	require.Equal(t, 2*time.Hour, stats.Min.Duration())
+	require.Equal(t, 3*time.Hour, stats.Max.Duration())
+	require.Equal(t, time.Date(2024, time.March, 10, 0, 0, 0, 0, newYork), stats.Max.From)
+
+	// Clocks go back from 02:00 to 01:00 on 2024-11-03
+	from = time.Date(2024, time.November, 2, 0, 0, 0, 0, newYork)
+	stats = Intervals(MustParse("0 */2 * * *"), from, 3*24*time.Hour)
+	require.Equal(t, 2*time.Hour, stats.Min.Duration())
+	require.Equal(t, 3*time.Hour, stats.Max.Duration())
+	require.Equal(t, time.Date(2024, time.November, 3, 0, 0, 0, 0, newYork), stats.Max.From)
+}
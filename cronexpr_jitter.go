@@ -0,0 +1,135 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_jitter.go
+ * Version: 1.0
+ * License: pick the one which suits you :
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"time"
+)
+
+/******************************************************************************/
+
+// A JitteredExpression wraps an Expression and delays each of its matching
+// time instants by a pseudo-random amount, which is useful to spread the load
+// of many jobs sharing the same schedule.
+//
+// The delay applied to a given time instant only depends on the seed and on
+// the time instant itself, so that a job always fires at the same jittered
+// time instants whatever the time at which they are queried.
+type JitteredExpression struct {
+	expr      *Expression
+	maxJitter time.Duration
+	seed      int64
+}
+
+/******************************************************************************/
+
+// Jittered returns a new JitteredExpression pointer, which delays each time
+// instant matching `schedule` by up to `maxJitter`. The delay is derived from
+// `seed`: using a different seed for each job spreads them apart, while using
+// the same seed always yields the same time instants.
+//
+// A jittered time instant never reaches the following time instant matching
+// `schedule`, so the delay is reduced whenever `maxJitter` is larger than the
+// gap between two consecutive matching time instants.
+func Jittered(schedule *Expression, maxJitter time.Duration, seed int64) *JitteredExpression {
+	return &JitteredExpression{
+		expr:      schedule,
+		maxJitter: maxJitter,
+		seed:      seed,
+	}
+}
+
+/******************************************************************************/
+
+// Next returns the closest jittered time instant immediately following
+// `fromTime`.
+//
+// The `time.Location` of the returned time instant is the same as that of
+// `fromTime`.
+//
+// The zero value of time.Time is returned if no matching time instant exists
+// or if a `fromTime` is itself a zero value.
+func (jexpr *JitteredExpression) Next(fromTime time.Time) time.Time {
+	if fromTime.IsZero() || jexpr.maxJitter <= 0 {
+		return jexpr.expr.Next(fromTime)
+	}
+
+	// A time instant scheduled up to `maxJitter` before `fromTime` may
+	// still be jittered past it, so start looking from there.
+	t := jexpr.expr.Next(fromTime.Add(-jexpr.maxJitter))
+	for !t.IsZero() {
+		following := jexpr.expr.Next(t)
+		if jittered := t.Add(jexpr.jitter(t, following)); jittered.After(fromTime) {
+			return jittered
+		}
+		t = following
+	}
+	return t
+}
+
+/******************************************************************************/
+
+// NextN returns a slice of `n` closest jittered time instants immediately
+// following `fromTime`.
+//
+// The time instants in the returned slice are in chronological ascending order.
+// The `time.Location` of the returned time instants is the same as that of
+// `fromTime`.
+//
+// A slice with len between [0-`n`] is returned, that is, if not enough existing
+// matching time instants exist, the number of returned entries will be less
+// than `n`.
+func (jexpr *JitteredExpression) NextN(fromTime time.Time, n uint) []time.Time {
+	nextTimes := make([]time.Time, 0, n)
+	if n > 0 {
+		fromTime = jexpr.Next(fromTime)
+		for {
+			if fromTime.IsZero() {
+				break
+			}
+			nextTimes = append(nextTimes, fromTime)
+			n -= 1
+			if n == 0 {
+				break
+			}
+			fromTime = jexpr.Next(fromTime)
+		}
+	}
+	return nextTimes
+}
+
+/******************************************************************************/
+
+// jitter returns the delay to apply to the scheduled time instant `t`, which
+// is always shorter than the gap to the `following` scheduled time instant.
+func (jexpr *JitteredExpression) jitter(t, following time.Time) time.Duration {
+	limit := jexpr.maxJitter
+	if !following.IsZero() && following.Sub(t) < limit {
+		limit = following.Sub(t)
+	}
+	if limit <= 0 {
+		return 0
+	}
+	return time.Duration(splitmix64(uint64(jexpr.seed)^uint64(t.Unix())) % uint64(limit))
+}
+
+// splitmix64 scrambles `x` into a well distributed pseudo-random value, see
+// <https://prng.di.unimi.it/splitmix64.c>
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
@@ -0,0 +1,78 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_jitter_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+/******************************************************************************/
+
+func TestJittered_Bounds(t *testing.T) {
+	from := time.Date(2019, time.March, 9, 0, 0, 0, 0, time.UTC)
+	for _, cron := range []string{"* * * * *", "0 * * * *", "*/10 * * * * * *", "* * * * * * *"} {
+		t.Run(cron, func(t *testing.T) {
+			expr := MustParse(cron)
+			jexpr := Jittered(expr, 30*time.Second, 42)
+
+			jittered := jexpr.NextN(from, 50)
+			require.Len(t, jittered, 50)
+			require.True(t, jittered[0].After(from), "%v is not after %v", jittered[0], from)
+			var previous time.Time
+			for _, jittered := range jittered {
+				// Find the latest scheduled time instant not after the jittered one
+				scheduled := expr.Next(jittered.Add(-31 * time.Second))
+				following := expr.Next(scheduled)
+				for !following.After(jittered) {
+					scheduled, following = following, expr.Next(following)
+				}
+				require.True(t, jittered.Sub(scheduled) < 30*time.Second, "%v is jittered too much from %v", jittered, scheduled)
+				if !previous.IsZero() {
+					require.Equal(t, expr.Next(previous), scheduled, "%v does not follow %v", jittered, previous)
+				}
+				previous = scheduled
+			}
+		})
+	}
+}
+
+func TestJittered_Reproducible(t *testing.T) {
+	expr := MustParse("0 */5 * * *")
+	from := time.Date(2019, time.March, 9, 0, 0, 0, 0, time.UTC)
+
+	a := Jittered(expr, 30*time.Second, 1).NextN(from, 20)
+	b := Jittered(expr, 30*time.Second, 1).NextN(from, 20)
+	c := Jittered(expr, 30*time.Second, 2).NextN(from, 20)
+	require.Equal(t, a, b)
+	require.NotEqual(t, a, c)
+
+	// Starting from within a jitter window yields the same time instants
+	jexpr := Jittered(expr, 30*time.Second, 1)
+	for i := 1; i < len(a); i++ {
+		require.Equal(t, a[i], jexpr.Next(a[i-1]))
+		require.Equal(t, a[i], jexpr.Next(a[i].Add(-time.Nanosecond)))
+	}
+}
+
+func TestJittered_NoJitter(t *testing.T) {
+	expr := MustParse("0 0 29 2 *")
+	from := time.Date(2013, time.August, 31, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, expr.NextN(from, 5), Jittered(expr, 0, 7).NextN(from, 5))
+	require.True(t, Jittered(expr, time.Minute, 7).Next(time.Time{}).IsZero())
+	require.True(t, Jittered(MustParse("* * * * * 1980"), time.Minute, 7).Next(from).IsZero())
+}
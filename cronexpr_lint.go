@@ -0,0 +1,205 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_lint.go
+ * Version: 1.0
+ * License: pick the one which suits you :
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+/******************************************************************************/
+
+// A WarningCode identifies the kind of a Warning. Codes are stable, so that
+// they can be relied upon to filter or silence warnings.
+type WarningCode string
+
+const (
+	// NeverFires reports an expression which matches no time instant.
+	NeverFires WarningCode = "never-fires"
+	// SkipsMonths reports days of month which never occur in some of the
+	// months of the expression.
+	SkipsMonths WarningCode = "skips-months"
+	// EveryMinute reports an expression which fires every minute, whereas
+	// a specific minute was probably meant.
+	EveryMinute WarningCode = "every-minute"
+	// DayOfMonthOrDayOfWeek reports that both the day-of-month and
+	// day-of-week fields are restricted, so that the expression fires when
+	// either of them matches.
+	DayOfMonthOrDayOfWeek WarningCode = "dom-or-dow"
+	// UnevenStep reports a step which does not divide the range of its
+	// field, leaving a shorter or longer gap when the field wraps around.
+	UnevenStep WarningCode = "uneven-step"
+)
+
+// A Warning reports a valid but suspicious part of an expression.
+type Warning struct {
+	Code WarningCode
+	// Field is the name of the field the warning is about.
+	Field string
+	// Pos and End are the byte offsets of the suspicious text within the
+	// expression, as passed to Parse.
+	Pos, End int
+	Message  string
+}
+
+// String returns the warning as `offset: [code] message`.
+func (w Warning) String() string {
+	return fmt.Sprintf("%d: [%s] %s", w.Pos, w.Code, w.Message)
+}
+
+/******************************************************************************/
+
+// Lint returns the warnings about suspicious parts of `expr`, in the order of
+// the fields they are about.
+func Lint(expr *Expression) []Warning {
+	l := linter{expr: expr, warnings: []Warning{}}
+	l.lintStep(secondField, secondDescriptor)
+	l.lintEveryMinute()
+	l.lintStep(minuteField, minuteDescriptor)
+	l.lintStep(hourField, hourDescriptor)
+	if !l.lintNeverFires() {
+		l.lintSkipsMonths()
+	}
+	l.lintStep(monthField, monthDescriptor)
+	l.lintDayOfMonthOrDayOfWeek()
+	l.lintStep(dowField, dowDescriptor)
+	return l.warnings
+}
+
+/******************************************************************************/
+
+type linter struct {
+	expr     *Expression
+	warnings []Warning
+}
+
+func (l *linter) warn(code WarningCode, field int, beg, end int, format string, args ...interface{}) {
+	l.warnings = append(l.warnings, Warning{
+		Code:    code,
+		Field:   fieldNames[field],
+		Pos:     beg,
+		End:     end,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *linter) warnField(code WarningCode, field int, format string, args ...interface{}) {
+	located := l.expr.fields[field]
+	l.warn(code, field, located.beg, located.end, format, args...)
+}
+
+var fieldNames = [fieldCount]string{
+	secondDescriptor.name,
+	minuteDescriptor.name,
+	hourDescriptor.name,
+	domDescriptor.name,
+	monthDescriptor.name,
+	dowDescriptor.name,
+	yearDescriptor.name,
+}
+
+/******************************************************************************/
+
+// lintNeverFires reports whether the expression matches no day at all.
+func (l *linter) lintNeverFires() bool {
+	if l.expr.satisfiable {
+		return false
+	}
+	if field := l.expr.emptyField(); field >= 0 {
+		l.warnField(NeverFires, field, "the %s field matches no value, the expression never fires", fieldNames[field])
+		return true
+	}
+	field := domField
+	if !l.expr.daysOfMonthRestricted {
+		field = dowField
+	}
+	l.warnField(NeverFires, field, "no day of the selected months matches, the expression never fires")
+	return true
+}
+
+func (l *linter) lintSkipsMonths() {
+	// Days of week match some day of every month
+	if !l.expr.daysOfMonthRestricted || l.expr.daysOfWeekRestricted {
+		return
+	}
+	skipped := []string{}
+	for _, month := range l.expr.monthList {
+		matches := false
+		for _, year := range l.expr.yearList {
+			if l.expr.actualDaysOfMonth(year, month) != 0 {
+				matches = true
+				break
+			}
+		}
+		if !matches {
+			skipped = append(skipped, time.Month(month).String())
+		}
+	}
+	if len(skipped) > 0 {
+		l.warnField(SkipsMonths, domField, "no selected day of month occurs in %s, the expression skips it", strings.Join(skipped, ", "))
+	}
+}
+
+func (l *linter) lintEveryMinute() {
+	if len(l.expr.secondList) != 1 || len(l.expr.minuteList) != len(minuteDescriptor.defaultList) {
+		return
+	}
+	outcome := "once an hour"
+	if len(l.expr.hourList) != len(hourDescriptor.defaultList) {
+		outcome = "once in each selected hour"
+	}
+	l.warnField(EveryMinute, minuteField, "the expression fires every minute, use a single minute such as `0` to fire %s", outcome)
+}
+
+func (l *linter) lintDayOfMonthOrDayOfWeek() {
+	if !l.expr.daysOfMonthRestricted || !l.expr.daysOfWeekRestricted {
+		return
+	}
+	dom, dow := l.expr.fields[domField], l.expr.fields[dowField]
+	l.warn(DayOfMonthOrDayOfWeek, dowField, dom.beg, dow.end,
+		"both day of month and day of week are restricted, the expression fires on days matching either of them")
+}
+
+// lintStep reports the steps of a field which do not evenly divide the range
+// of values of the field.
+func (l *linter) lintStep(field int, desc fieldDescriptor) {
+	located := l.expr.fields[field]
+	directives, err := genericFieldParse(located.text, desc)
+	// Steps are only worth checking when alone in their field, and when the
+	// field is located in the expression
+	if err != nil || len(directives) != 1 || located.beg < 0 {
+		return
+	}
+	directive := directives[0]
+	if directive.kind != span || directive.step == 1 || directive.last != desc.max {
+		return
+	}
+	lastValue := directive.first + (directive.last-directive.first)/directive.step*directive.step
+	// Gap from the last value to the first one of the next cycle
+	gap := desc.max + 1 - lastValue + directive.first - desc.min
+	if gap == directive.step {
+		return
+	}
+	// Point at the step itself, unless the field comes from a predefined
+	// expression
+	beg, end := located.beg, located.end
+	if l.expr.expression[beg:end] == located.text {
+		beg, end = located.beg+directive.sbeg, located.beg+directive.send
+	}
+	l.warn(UnevenStep, field, beg, end,
+		"step of %d does not divide the %d values of the %s field, the gap between %d and %d is %d",
+		directive.step, desc.max-desc.min+1, desc.name, lastValue, directive.first, gap)
+}
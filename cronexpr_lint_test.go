@@ -0,0 +1,70 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_lint_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+/******************************************************************************/
+
+var lintTests = []struct {
+	expr     string
+	warnings []string // `code field pos-end`
+}{
+	{"0 0 * * *", nil},
+	{"0 */6 * * *", nil},
+	{"0 */15 * * *", []string{"uneven-step hour 2-6"}},
+	{"0 0 29 2 *", nil},
+	{"0 0 31 2 *", []string{"never-fires day-of-month 4-6"}},
+	{"0 5-3 * * *", []string{"never-fires hour 2-5"}},
+	{"0 0 30 * *", []string{"skips-months day-of-month 4-6"}},
+	{"0 0 31 * *", []string{"skips-months day-of-month 4-6"}},
+	{"* * * * *", []string{"every-minute minute 0-1"}},
+	{"* 9 * * 1-5", []string{"every-minute minute 0-1"}},
+	{"* * * * * * *", nil},
+	{"0 9 1 * 1", []string{"dom-or-dow day-of-week 4-9"}},
+	{"*/7 * * * *", []string{"uneven-step minute 0-3"}},
+	{"0 9  */5 *  *", nil},
+	{"0 0 1 */5 *", []string{"uneven-step month 6-9"}},
+	{"0 0 * * */4", []string{"uneven-step day-of-week 8-11"}},
+	{"0 0 0 * * * 2020/7", nil},
+	{"0 0 1 2/5 * *", []string{"uneven-step month 6-9"}},
+	{"*/11 * * * * * *", []string{"uneven-step second 0-4"}},
+	{"@hourly", nil},
+}
+
+func TestLint(t *testing.T) {
+	for _, test := range lintTests {
+		warnings := []string{}
+		for _, w := range Lint(MustParse(test.expr)) {
+			warnings = append(warnings, fmt.Sprintf("%s %s %d-%d", w.Code, w.Field, w.Pos, w.End))
+		}
+		if test.warnings == nil {
+			test.warnings = []string{}
+		}
+		require.Equal(t, test.warnings, warnings, test.expr)
+	}
+
+	require.Equal(t, "4: [skips-months] no selected day of month occurs in February, the expression skips it",
+		Lint(MustParse("0 0 30 * *"))[0].String())
+	require.Equal(t, "0: [every-minute] the expression fires every minute, use a single minute such as `0` to fire once an hour",
+		Lint(MustParse("* * * * *"))[0].String())
+	require.Equal(t, "0: [every-minute] the expression fires every minute, use a single minute such as `0` to fire once in each selected hour",
+		Lint(MustParse("* 9 * * 1-5"))[0].String())
+}
@@ -0,0 +1,343 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_locale.go
+ * Version: 1.0
+ * License: pick the one which suits you :
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+// A Locale provides the words and phrase templates used to describe cron
+// expressions in a given language, see DescribeIn.
+type Locale interface {
+	// MonthName returns the name of `month`, in the range [1-12].
+	MonthName(month int) string
+	// DayOfWeekName returns the name of `dow`, in the range [0-6], 0 being
+	// Sunday.
+	DayOfWeekName(dow int) string
+	// WeekOrdinal returns the ordinal used for the `week`-th occurrence of a
+	// day of week in a month, in the range [1-5], i.e. "third" in "on the
+	// third Thursday of the month".
+	WeekOrdinal(week int) string
+	// Message returns the `fmt` template of the phrase identified by `key`,
+	// or an empty string if the locale does not provide it, in which case
+	// the English phrase is used.
+	Message(key string) string
+}
+
+/******************************************************************************/
+
+// A Catalog is a Locale backed by static tables of words and phrase
+// templates. The keys of Messages are those of the English catalog.
+//
+// The abbreviations are not used in descriptions, but are accepted by the
+// parser along with the full names, see WithLocaleNames.
+type Catalog struct {
+	MonthNames             [12]string
+	MonthAbbreviations     [12]string
+	DayOfWeekNames         [7]string
+	DayOfWeekAbbreviations [7]string
+	WeekOrdinals           [5]string
+	Messages               map[string]string
+}
+
+// MonthName returns the name of `month`, in the range [1-12].
+func (c *Catalog) MonthName(month int) string {
+	return c.MonthNames[month-1]
+}
+
+// DayOfWeekName returns the name of `dow`, in the range [0-6].
+func (c *Catalog) DayOfWeekName(dow int) string {
+	return c.DayOfWeekNames[dow]
+}
+
+// WeekOrdinal returns the ordinal of the `week`-th week, in the range [1-5].
+func (c *Catalog) WeekOrdinal(week int) string {
+	return c.WeekOrdinals[week-1]
+}
+
+// Message returns the phrase template identified by `key`.
+func (c *Catalog) Message(key string) string {
+	return c.Messages[key]
+}
+
+/******************************************************************************/
+
+var (
+	// English describes cron expressions in English.
+	English = &Catalog{
+		MonthNames: [12]string{
+			"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December",
+		},
+		MonthAbbreviations: [12]string{
+			"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+			"Jul", "Aug", "Sep", "Oct", "Nov", "Dec",
+		},
+		DayOfWeekNames: [7]string{
+			"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
+		},
+		DayOfWeekAbbreviations: [7]string{
+			"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat",
+		},
+		WeekOrdinals: [5]string{
+			"first", "second", "third", "fourth", "fifth",
+		},
+		Messages: map[string]string{
+			"and": "and",
+			"or":  "or",
+
+			"@yearly":   "Once a year, at 00:00 on January 1",
+			"@annually": "Once a year, at 00:00 on January 1",
+			"@monthly":  "Once a month, at 00:00 on day 1",
+			"@weekly":   "Once a week, at 00:00 on Sunday",
+			"@daily":    "Once a day, at 00:00",
+			"@hourly":   "Once an hour, at the beginning of the hour",
+
+			"never": "Never, as no time instant matches",
+
+			"time.at": "at %s",
+
+			"second.every":            "every second",
+			"second.at":               "at %d seconds past the minute",
+			"second.atOne":            "at %d second past the minute",
+			"second.atList":           "at %s seconds past the minute",
+			"second.everyBetween":     "every second between %d and %d seconds past the minute",
+			"second.everyN":           "every %d seconds",
+			"second.everyNStartingAt": "every %d seconds starting at %d seconds past the minute",
+			"second.everyNBetween":    "every %d seconds between %d and %d seconds past the minute",
+
+			"minute.every":            "every minute",
+			"minute.at":               "at %d minutes past the hour",
+			"minute.atOne":            "at %d minute past the hour",
+			"minute.atList":           "at %s minutes past the hour",
+			"minute.everyBetween":     "every minute between %d and %d minutes past the hour",
+			"minute.everyN":           "every %d minutes",
+			"minute.everyNStartingAt": "every %d minutes starting at %d minutes past the hour",
+			"minute.everyNBetween":    "every %d minutes between %d and %d minutes past the hour",
+
+			"hour.every":             "every hour",
+			"hour.everyFromThrough":  "every hour from %s through %s",
+			"hour.everyN":            "every %d hours",
+			"hour.everyNStartingAt":  "every %d hours starting at %s",
+			"hour.everyNFromThrough": "every %d hours from %s through %s",
+			"hour.between":           "between %s and %s",
+			"hour.everyNBetween":     "every %d hours between %s and %s",
+			"hour.during":            "during the %s hours",
+
+			"dom.one":               "on day %d of the month",
+			"dom.list":              "on days %s of the month",
+			"dom.fromThrough":       "on days %d through %d of the month",
+			"dom.everyNStartingOn":  "every %d days starting on day %d of the month",
+			"dom.everyNFromThrough": "every %d days from day %d through %d of the month",
+			"dom.workday":           "on the weekday nearest day %d of the month",
+			"dom.last":              "on the last day of the month",
+			"dom.lastWorkday":       "on the last weekday of the month",
+
+			"dow.list":        "on %s",
+			"dow.fromThrough": "on %s through %s",
+			"dow.specific":    "on the %s %s of the month",
+			"dow.last":        "on the last %s of the month",
+
+			"month.one":               "only in %s",
+			"month.list":              "only in %s",
+			"month.fromThrough":       "from %s through %s",
+			"month.everyN":            "every %d months",
+			"month.everyNStartingIn":  "every %d months starting in %s",
+			"month.everyNFromThrough": "every %d months from %s through %s",
+
+			"year.one":               "only in %d",
+			"year.list":              "only in %s",
+			"year.fromThrough":       "from %d through %d",
+			"year.everyNStartingIn":  "every %d years starting in %d",
+			"year.everyNFromThrough": "every %d years from %d through %d",
+		},
+	}
+
+	// German describes cron expressions in German.
+	German = &Catalog{
+		MonthNames: [12]string{
+			"Januar", "Februar", "März", "April", "Mai", "Juni",
+			"Juli", "August", "September", "Oktober", "November", "Dezember",
+		},
+		MonthAbbreviations: [12]string{
+			"Jan", "Feb", "Mär", "Apr", "Mai", "Jun",
+			"Jul", "Aug", "Sep", "Okt", "Nov", "Dez",
+		},
+		DayOfWeekNames: [7]string{
+			"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag",
+		},
+		DayOfWeekAbbreviations: [7]string{
+			"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa",
+		},
+		WeekOrdinals: [5]string{
+			"ersten", "zweiten", "dritten", "vierten", "fünften",
+		},
+		Messages: map[string]string{
+			"and": "und",
+			"or":  "oder",
+
+			"@yearly":   "Einmal im Jahr, um 00:00 am 1. Januar",
+			"@annually": "Einmal im Jahr, um 00:00 am 1. Januar",
+			"@monthly":  "Einmal im Monat, um 00:00 am Tag 1",
+			"@weekly":   "Einmal pro Woche, um 00:00 am Sonntag",
+			"@daily":    "Einmal am Tag, um 00:00",
+			"@hourly":   "Einmal pro Stunde, zu Beginn der Stunde",
+
+			"never": "Nie, da kein Zeitpunkt zutrifft",
+
+			"time.at": "um %s",
+
+			"second.every":            "jede Sekunde",
+			"second.at":               "um %d Sekunden nach der vollen Minute",
+			"second.atOne":            "um %d Sekunde nach der vollen Minute",
+			"second.atList":           "um %s Sekunden nach der vollen Minute",
+			"second.everyBetween":     "jede Sekunde zwischen %d und %d Sekunden nach der vollen Minute",
+			"second.everyN":           "alle %d Sekunden",
+			"second.everyNStartingAt": "alle %d Sekunden ab %d Sekunden nach der vollen Minute",
+			"second.everyNBetween":    "alle %d Sekunden zwischen %d und %d Sekunden nach der vollen Minute",
+
+			"minute.every":            "jede Minute",
+			"minute.at":               "um %d Minuten nach der vollen Stunde",
+			"minute.atOne":            "um %d Minute nach der vollen Stunde",
+			"minute.atList":           "um %s Minuten nach der vollen Stunde",
+			"minute.everyBetween":     "jede Minute zwischen %d und %d Minuten nach der vollen Stunde",
+			"minute.everyN":           "alle %d Minuten",
+			"minute.everyNStartingAt": "alle %d Minuten ab %d Minuten nach der vollen Stunde",
+			"minute.everyNBetween":    "alle %d Minuten zwischen %d und %d Minuten nach der vollen Stunde",
+
+			"hour.every":             "jede Stunde",
+			"hour.everyFromThrough":  "jede Stunde von %s bis %s",
+			"hour.everyN":            "alle %d Stunden",
+			"hour.everyNStartingAt":  "alle %d Stunden ab %s",
+			"hour.everyNFromThrough": "alle %d Stunden von %s bis %s",
+			"hour.between":           "zwischen %s und %s",
+			"hour.everyNBetween":     "alle %d Stunden zwischen %s und %s",
+			"hour.during":            "während der Stunden ab %s",
+
+			"dom.one":               "am Tag %d des Monats",
+			"dom.list":              "an den Tagen %s des Monats",
+			"dom.fromThrough":       "an den Tagen %d bis %d des Monats",
+			"dom.everyNStartingOn":  "alle %d Tage ab dem Tag %d des Monats",
+			"dom.everyNFromThrough": "alle %d Tage vom Tag %d bis %d des Monats",
+			"dom.workday":           "am Werktag, der dem Tag %d des Monats am nächsten liegt",
+			"dom.last":              "am letzten Tag des Monats",
+			"dom.lastWorkday":       "am letzten Werktag des Monats",
+
+			"dow.list":        "am %s",
+			"dow.fromThrough": "von %s bis %s",
+			"dow.specific":    "am %s %s des Monats",
+			"dow.last":        "am letzten %s des Monats",
+
+			"month.one":               "nur im %s",
+			"month.list":              "nur im %s",
+			"month.fromThrough":       "von %s bis %s",
+			"month.everyN":            "alle %d Monate",
+			"month.everyNStartingIn":  "alle %d Monate ab %s",
+			"month.everyNFromThrough": "alle %d Monate von %s bis %s",
+
+			"year.one":               "nur im Jahr %d",
+			"year.list":              "nur in den Jahren %s",
+			"year.fromThrough":       "von %d bis %d",
+			"year.everyNStartingIn":  "alle %d Jahre ab %d",
+			"year.everyNFromThrough": "alle %d Jahre von %d bis %d",
+		},
+	}
+
+	// French describes cron expressions in French.
+	French = &Catalog{
+		MonthNames: [12]string{
+			"janvier", "février", "mars", "avril", "mai", "juin",
+			"juillet", "août", "septembre", "octobre", "novembre", "décembre",
+		},
+		MonthAbbreviations: [12]string{
+			"janv", "févr", "mars", "avr", "mai", "juin",
+			"juil", "août", "sept", "oct", "nov", "déc",
+		},
+		DayOfWeekNames: [7]string{
+			"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi",
+		},
+		DayOfWeekAbbreviations: [7]string{
+			"dim", "lun", "mar", "mer", "jeu", "ven", "sam",
+		},
+		WeekOrdinals: [5]string{
+			"premier", "deuxième", "troisième", "quatrième", "cinquième",
+		},
+		Messages: map[string]string{
+			"and": "et",
+			"or":  "ou",
+
+			"@yearly":   "Une fois par an, à 00:00 le 1er janvier",
+			"@annually": "Une fois par an, à 00:00 le 1er janvier",
+			"@monthly":  "Une fois par mois, à 00:00 le jour 1",
+			"@weekly":   "Une fois par semaine, à 00:00 le dimanche",
+			"@daily":    "Une fois par jour, à 00:00",
+			"@hourly":   "Une fois par heure, au début de l'heure",
+
+			"never": "Jamais, aucun instant ne correspond",
+
+			"time.at": "à %s",
+
+			"second.every":            "chaque seconde",
+			"second.at":               "à la seconde %d de chaque minute",
+			"second.atOne":            "à la seconde %d de chaque minute",
+			"second.atList":           "aux secondes %s de chaque minute",
+			"second.everyBetween":     "chaque seconde de la seconde %d à la seconde %d de chaque minute",
+			"second.everyN":           "toutes les %d secondes",
+			"second.everyNStartingAt": "toutes les %d secondes à partir de la seconde %d de chaque minute",
+			"second.everyNBetween":    "toutes les %d secondes de la seconde %d à la seconde %d de chaque minute",
+
+			"minute.every":            "chaque minute",
+			"minute.at":               "à la minute %d de chaque heure",
+			"minute.atOne":            "à la minute %d de chaque heure",
+			"minute.atList":           "aux minutes %s de chaque heure",
+			"minute.everyBetween":     "chaque minute de la minute %d à la minute %d de chaque heure",
+			"minute.everyN":           "toutes les %d minutes",
+			"minute.everyNStartingAt": "toutes les %d minutes à partir de la minute %d de chaque heure",
+			"minute.everyNBetween":    "toutes les %d minutes de la minute %d à la minute %d de chaque heure",
+
+			"hour.every":             "toutes les heures",
+			"hour.everyFromThrough":  "toutes les heures de %s à %s",
+			"hour.everyN":            "toutes les %d heures",
+			"hour.everyNStartingAt":  "toutes les %d heures à partir de %s",
+			"hour.everyNFromThrough": "toutes les %d heures de %s à %s",
+			"hour.between":           "entre %s et %s",
+			"hour.everyNBetween":     "toutes les %d heures entre %s et %s",
+			"hour.during":            "durant les heures commençant à %s",
+
+			"dom.one":               "le jour %d du mois",
+			"dom.list":              "les jours %s du mois",
+			"dom.fromThrough":       "du jour %d au jour %d du mois",
+			"dom.everyNStartingOn":  "tous les %d jours à partir du jour %d du mois",
+			"dom.everyNFromThrough": "tous les %d jours du jour %d au jour %d du mois",
+			"dom.workday":           "le jour ouvré le plus proche du jour %d du mois",
+			"dom.last":              "le dernier jour du mois",
+			"dom.lastWorkday":       "le dernier jour ouvré du mois",
+
+			"dow.list":        "le %s",
+			"dow.fromThrough": "du %s au %s",
+			"dow.specific":    "le %s %s du mois",
+			"dow.last":        "le dernier %s du mois",
+
+			"month.one":               "uniquement en %s",
+			"month.list":              "uniquement en %s",
+			"month.fromThrough":       "entre %s et %s",
+			"month.everyN":            "tous les %d mois",
+			"month.everyNStartingIn":  "tous les %d mois en commençant en %s",
+			"month.everyNFromThrough": "tous les %d mois entre %s et %s",
+
+			"year.one":               "uniquement en %d",
+			"year.list":              "uniquement en %s",
+			"year.fromThrough":       "de %d à %d",
+			"year.everyNStartingIn":  "tous les %d ans à partir de %d",
+			"year.everyNFromThrough": "tous les %d ans de %d à %d",
+		},
+	}
+)
@@ -0,0 +1,61 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_names.go
+ * Version: 1.0
+ * License: pick the one which suits you :
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"sort"
+)
+
+/******************************************************************************/
+
+// PredefinedExpressions returns the predefined expressions accepted by Parse,
+// such as `@daily`.
+func PredefinedExpressions() []string {
+	names := []string{}
+	for i := 0; i < len(predefinedExpressions); i += 2 {
+		names = append(names, predefinedExpressions[i])
+	}
+	return names
+}
+
+// MonthNames returns the names of months accepted by Parse in the month
+// field, abbreviated and full, from January to December.
+func MonthNames() []string {
+	return tokenNames(monthTokens)
+}
+
+// DayOfWeekNames returns the names of days of the week accepted by Parse in
+// the day-of-week field, abbreviated and full, from Sunday to Saturday.
+func DayOfWeekNames() []string {
+	return tokenNames(dowTokens)
+}
+
+// tokenNames returns the non-numeric tokens of a field, ordered by value then
+// by length.
+func tokenNames(tokens map[string]int) []string {
+	names := []string{}
+	for name := range tokens {
+		if name[0] < '0' || name[0] > '9' {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if vi, vj := tokens[names[i]], tokens[names[j]]; vi != vj {
+			return vi < vj
+		}
+		return len(names[i]) < len(names[j])
+	})
+	return names
+}
@@ -0,0 +1,48 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_names_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+/******************************************************************************/
+
+func TestNames(t *testing.T) {
+	require.Equal(t, []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@hourly"}, PredefinedExpressions())
+	require.Equal(t, []string{
+		"jan", "january", "feb", "february", "mar", "march", "apr", "april", "may", "jun", "june",
+		"jul", "july", "aug", "august", "sep", "september", "oct", "october", "nov", "november", "dec", "december",
+	}, MonthNames())
+	require.Equal(t, []string{
+		"sun", "sunday", "mon", "monday", "tue", "tuesday", "wed", "wednesday", "thu", "thursday", "fri", "friday", "sat", "saturday",
+	}, DayOfWeekNames())
+
+	// All names are accepted by the parser
+	for _, name := range PredefinedExpressions() {
+		_, err := Parse(name)
+		require.NoError(t, err, name)
+	}
+	for _, name := range MonthNames() {
+		_, err := Parse("0 0 1 " + name + " *")
+		require.NoError(t, err, name)
+	}
+	for _, name := range DayOfWeekNames() {
+		_, err := Parse("0 0 * * " + name)
+		require.NoError(t, err, name)
+	}
+}
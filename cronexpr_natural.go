@@ -0,0 +1,660 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_natural.go
+ * Version: 1.0
+ * License: pick the one which suits you :
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/******************************************************************************/
+
+// An UnknownWord is a word which was not understood by NaturalToCron, along
+// with its byte offset in the text.
+type UnknownWord struct {
+	Word   string
+	Offset int
+}
+
+// A NaturalLanguageError is returned by NaturalToCron and ParseNatural when
+// the text contains words outside of the supported grammar.
+type NaturalLanguageError struct {
+	Text    string
+	Unknown []UnknownWord
+}
+
+func (e *NaturalLanguageError) Error() string {
+	words := make([]string, len(e.Unknown))
+	for i, unknown := range e.Unknown {
+		words[i] = fmt.Sprintf("'%s' at offset %d", unknown.Word, unknown.Offset)
+	}
+	return fmt.Sprintf("unrecognized word(s) in \"%s\": %s", e.Text, strings.Join(words, ", "))
+}
+
+/******************************************************************************/
+
+// ParseNatural returns a new Expression pointer for a schedule written in
+// plain English, such as "every weekday at 9:30am" or "last Friday of every
+// month at 5pm". See NaturalToCron for the supported grammar.
+func ParseNatural(text string) (*Expression, error) {
+	cronLine, err := NaturalToCron(text)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(cronLine)
+}
+
+// NaturalToCron translates a schedule written in plain English into a five
+// fields cron expression, i.e. "every weekday at 9:30am" into `30 9 * * 1-5`.
+//
+// The translation is rule-based and understands:
+//   - frequencies: "every minute", "every 15 minutes", "every other hour",
+//     "hourly", "daily", "weekly", "monthly", "quarterly", "yearly", ...,
+//     as long as the step fits its field, e.g. up to "every 59 minutes"
+//   - times of day: "at 9:30am", "at 17:00", "at noon", "at midnight", or a
+//     period such as "between 9am and 5pm"
+//   - days of week: "on Mondays", "Monday through Friday", "every weekday",
+//     "on weekends", "the second Tuesday", "the last Friday of the month"
+//   - days of month: "on the 15th", "on day 1", "the last day of the month",
+//     "the last weekday of the month", "the weekday nearest the 15th"
+//   - months: "in January", "from March to June"
+//
+// A *NaturalLanguageError listing the unknown words is returned if the text
+// contains words outside of this grammar.
+func NaturalToCron(text string) (string, error) {
+	p := naturalParser{text: text}
+	for _, loc := range naturalWordFinder.FindAllStringIndex(text, -1) {
+		p.words = append(p.words, naturalWord{strings.ToLower(text[loc[0]:loc[1]]), loc[0]})
+	}
+	if len(p.words) == 0 {
+		return "", fmt.Errorf("empty schedule")
+	}
+	for p.pos < len(p.words) {
+		if !p.parseClause() {
+			p.reject(0)
+			p.pos++
+		}
+	}
+	if len(p.unknown) > 0 {
+		return "", &NaturalLanguageError{Text: text, Unknown: p.unknown}
+	}
+	return p.cronLine()
+}
+
+/******************************************************************************/
+
+var (
+	naturalWordFinder = regexp.MustCompile(`[^\s,;.]+`)
+	naturalTimeFinder = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+	naturalNthFinder  = regexp.MustCompile(`^(\d{1,2})(st|nd|rd|th)$`)
+	naturalOrdinals   = map[string]int{
+		"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5,
+	}
+	naturalFillers = map[string]bool{
+		"and": true, "of": true, "the": true, "on": true, "in": true, "at": true,
+		"every": true, "each": true, "month": true,
+	}
+)
+
+type naturalWord struct {
+	text   string
+	offset int
+}
+
+type naturalParser struct {
+	text    string
+	words   []naturalWord
+	pos     int
+	unknown []UnknownWord
+
+	minute  string
+	hour    string
+	times   [][2]int
+	period  [][2]int
+	doms    []string
+	dows    []string
+	months  []string
+	dayStep string
+	weekly  bool
+	monthly bool
+	yearly  bool
+
+	// Indexes of the words of the start and end times of `period`
+	periodWords []int
+}
+
+func (p *naturalParser) peek(i int) string {
+	if p.pos+i < len(p.words) {
+		return p.words[p.pos+i].text
+	}
+	return ""
+}
+
+// accept consumes `words` if they come next, in order.
+func (p *naturalParser) accept(words ...string) bool {
+	for i, word := range words {
+		if p.peek(i) != word {
+			return false
+		}
+	}
+	p.pos += len(words)
+	return true
+}
+
+// acceptOfMonth consumes an optional "of the month" or "of every month".
+func (p *naturalParser) acceptOfMonth() {
+	_ = p.accept("of", "the", "month") || p.accept("of", "every", "month") ||
+		p.accept("of", "each", "month") || p.accept("of", "month")
+}
+
+// reject records the `i`-th next word as unknown.
+func (p *naturalParser) reject(i int) {
+	p.rejectWord(p.pos + i)
+}
+
+// rejectWord records the word at `index` as unknown.
+func (p *naturalParser) rejectWord(index int) {
+	offset := p.words[index].offset
+	p.unknown = append(p.unknown, UnknownWord{naturalWordFinder.FindString(p.text[offset:]), offset})
+}
+
+/******************************************************************************/
+
+func (p *naturalParser) parseClause() bool {
+	switch {
+	case p.parseFrequency(),
+		p.parseLast(),
+		p.parseNthDayOfWeek(),
+		p.parseNearestWeekday(),
+		p.parseDaysOfWeek(),
+		p.parseDaysOfMonth(),
+		p.parseMonths(),
+		p.parsePeriod(),
+		p.parseTimes():
+		return true
+	}
+	if naturalFillers[p.peek(0)] {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// "every 15 minutes", "every other day", "hourly", ...
+func (p *naturalParser) parseFrequency() bool {
+	switch p.peek(0) {
+	case "hourly":
+		p.hour = "*"
+	case "daily", "nightly":
+	case "weekly":
+		p.weekly = true
+	case "monthly":
+		p.monthly = true
+	case "quarterly":
+		p.months, p.monthly = []string{"*/3"}, true
+	case "yearly", "annually":
+		p.yearly = true
+	case "every", "each":
+		return p.parseEvery()
+	default:
+		return false
+	}
+	p.pos++
+	return true
+}
+
+func (p *naturalParser) parseEvery() bool {
+	step := ""
+	unit := p.peek(1)
+	if unit == "other" {
+		step, unit = "2", p.peek(2)
+	} else if n, err := strconv.Atoi(unit); err == nil && n > 0 {
+		step, unit = unit, p.peek(2)
+	}
+	if step != "" {
+		var desc fieldDescriptor
+		switch unit {
+		case "minutes", "minute":
+			desc, p.minute = minuteDescriptor, "*/"+step
+		case "hours", "hour":
+			desc, p.hour = hourDescriptor, "*/"+step
+		case "days", "day":
+			desc, p.dayStep = domDescriptor, "*/"+step
+		case "months", "month":
+			desc, p.months, p.monthly = monthDescriptor, []string{"*/" + step}, true
+		default:
+			return false
+		}
+		// A step larger than its field, such as "every 90 minutes", cannot be
+		// expressed by a cron expression
+		if n, _ := strconv.Atoi(step); n > desc.max {
+			p.reject(1)
+		}
+		p.pos += 3
+		return true
+	}
+	switch unit {
+	case "minute":
+		p.minute = "*"
+	case "hour":
+		p.hour = "*"
+	case "day", "night":
+	case "week":
+		p.weekly = true
+	case "month":
+		p.monthly = true
+	case "quarter":
+		p.months, p.monthly = []string{"*/3"}, true
+	case "year":
+		p.yearly = true
+	default:
+		return false
+	}
+	p.pos += 2
+	return true
+}
+
+// "last day of the month", "last weekday", "last Friday of every month"
+func (p *naturalParser) parseLast() bool {
+	if p.peek(0) != "last" {
+		return false
+	}
+	switch {
+	case p.peek(1) == "day":
+		p.doms = append(p.doms, "L")
+		p.pos += 2
+	case p.peek(1) == "weekday":
+		p.doms = append(p.doms, "LW")
+		p.pos += 2
+	case p.peek(1) == "business" && p.peek(2) == "day":
+		p.doms = append(p.doms, "LW")
+		p.pos += 3
+	default:
+		dow, ok := naturalDayOfWeek(p.peek(1))
+		if !ok {
+			return false
+		}
+		p.dows = append(p.dows, fmt.Sprintf("%dL", dow))
+		p.pos += 2
+	}
+	p.acceptOfMonth()
+	return true
+}
+
+// "second Tuesday of the month"
+func (p *naturalParser) parseNthDayOfWeek() bool {
+	nth, ok := naturalOrdinals[p.peek(0)]
+	if !ok {
+		return false
+	}
+	dow, ok := naturalDayOfWeek(p.peek(1))
+	if !ok {
+		return false
+	}
+	p.dows = append(p.dows, fmt.Sprintf("%d#%d", dow, nth))
+	p.pos += 2
+	p.acceptOfMonth()
+	return true
+}
+
+// "weekday nearest the 15th", "nearest weekday to the 15th"
+func (p *naturalParser) parseNearestWeekday() bool {
+	start := p.pos
+	if !p.accept("weekday", "nearest") && !p.accept("weekday", "closest") &&
+		!p.accept("nearest", "weekday") && !p.accept("closest", "weekday") {
+		return false
+	}
+	_ = p.accept("to")
+	_ = p.accept("the")
+	_ = p.accept("day")
+	dom, ok := naturalDayOfMonth(p.peek(0))
+	if !ok {
+		p.pos = start
+		return false
+	}
+	p.doms = append(p.doms, fmt.Sprintf("%dW", dom))
+	p.pos++
+	p.acceptOfMonth()
+	return true
+}
+
+// "weekdays", "weekends", "Monday", "Mondays", "Monday through Friday"
+func (p *naturalParser) parseDaysOfWeek() bool {
+	switch p.peek(0) {
+	case "weekday", "weekdays":
+		p.dows = append(p.dows, "1-5")
+		p.pos++
+		return true
+	case "weekend", "weekends":
+		p.dows = append(p.dows, "0", "6")
+		p.pos++
+		return true
+	case "business":
+		if p.peek(1) == "day" || p.peek(1) == "days" {
+			p.dows = append(p.dows, "1-5")
+			p.pos += 2
+			return true
+		}
+		return false
+	}
+	first, ok := naturalDayOfWeek(p.peek(0))
+	if !ok {
+		return false
+	}
+	if last, ok := naturalDayOfWeek(p.peek(2)); ok && naturalIsThrough(p.peek(1)) {
+		p.dows = append(p.dows, fmt.Sprintf("%d-%d", first, last))
+		p.pos += 3
+		return true
+	}
+	p.dows = append(p.dows, strconv.Itoa(first))
+	p.pos++
+	return true
+}
+
+// "the 15th", "day 15", "the 1st through the 15th"
+func (p *naturalParser) parseDaysOfMonth() bool {
+	start := p.pos
+	_ = p.accept("day")
+	first, ok := naturalDayOfMonth(p.peek(0))
+	if !ok || (p.pos == start && !naturalNthFinder.MatchString(p.peek(0))) {
+		p.pos = start
+		return false
+	}
+	p.pos++
+	if naturalIsThrough(p.peek(0)) {
+		through := p.pos
+		p.pos++
+		_ = p.accept("the")
+		_ = p.accept("day")
+		if last, ok := naturalDayOfMonth(p.peek(0)); ok {
+			p.doms = append(p.doms, fmt.Sprintf("%d-%d", first, last))
+			p.pos++
+			return true
+		}
+		p.pos = through
+	}
+	p.doms = append(p.doms, strconv.Itoa(first))
+	return true
+}
+
+// "January", "from March to June"
+func (p *naturalParser) parseMonths() bool {
+	start := p.pos
+	_ = p.accept("from")
+	first, ok := naturalMonth(p.peek(0))
+	if !ok {
+		p.pos = start
+		return false
+	}
+	if last, ok := naturalMonth(p.peek(2)); ok && naturalIsThrough(p.peek(1)) {
+		p.months = append(p.months, fmt.Sprintf("%d-%d", first, last))
+		p.pos += 3
+		return true
+	}
+	p.months = append(p.months, strconv.Itoa(first))
+	p.pos++
+	return true
+}
+
+// "between 9am and 5pm", "from 9:00 to 17:00"
+func (p *naturalParser) parsePeriod() bool {
+	var ok bool
+	var from, to [2]int
+	var fromWord, toWord int
+	start := p.pos
+	if p.accept("between") {
+		fromWord = p.pos
+		if from, ok = p.parseTime(true); ok && p.accept("and") {
+			toWord = p.pos
+			to, ok = p.parseTime(true)
+		} else {
+			ok = false
+		}
+	} else if p.accept("from") {
+		fromWord = p.pos
+		if from, ok = p.parseTime(true); ok && naturalIsThrough(p.peek(0)) {
+			p.pos++
+			toWord = p.pos
+			to, ok = p.parseTime(true)
+		} else {
+			ok = false
+		}
+	}
+	if !ok {
+		p.pos = start
+		return false
+	}
+	p.period = append(p.period, from, to)
+	p.periodWords = append(p.periodWords, fromWord, toWord)
+	return true
+}
+
+// "at 9am and 5pm", "at 9:00 and 9:30", "noon", "17:00"
+func (p *naturalParser) parseTimes() bool {
+	start := p.pos
+	at := p.accept("at")
+	t, ok := p.parseTime(at)
+	if !ok {
+		p.pos = start
+		return false
+	}
+	p.times = append(p.times, t)
+	for p.peek(0) == "and" {
+		p.pos++
+		if t, ok = p.parseTime(at); !ok {
+			p.pos--
+			break
+		}
+		p.times = append(p.times, t)
+	}
+	return true
+}
+
+// parseTime consumes a time of day, as an hour and a minute. A bare number
+// such as "9" is only considered to be a time if `bare` is true.
+func (p *naturalParser) parseTime(bare bool) ([2]int, bool) {
+	switch p.peek(0) {
+	case "noon", "midday":
+		p.pos++
+		return [2]int{12, 0}, true
+	case "midnight":
+		p.pos++
+		return [2]int{0, 0}, true
+	}
+	matches := naturalTimeFinder.FindStringSubmatch(p.peek(0))
+	if matches == nil {
+		return [2]int{}, false
+	}
+	hour, _ := strconv.Atoi(matches[1])
+	minute, _ := strconv.Atoi(matches[2])
+	suffix := matches[3]
+	consumed := 1
+	if suffix == "" && (p.peek(1) == "am" || p.peek(1) == "pm") {
+		suffix = p.peek(1)
+		consumed++
+	}
+	if suffix == "" && matches[2] == "" && !bare {
+		return [2]int{}, false
+	}
+	if suffix != "" {
+		if hour < 1 || hour > 12 {
+			return [2]int{}, false
+		}
+		hour %= 12
+		if suffix == "pm" {
+			hour += 12
+		}
+	}
+	if hour > 23 || minute > 59 {
+		return [2]int{}, false
+	}
+	p.pos += consumed
+	return [2]int{hour, minute}, true
+}
+
+/******************************************************************************/
+
+func naturalDayOfWeek(word string) (int, bool) {
+	word = strings.TrimSuffix(word, "s")
+	if len(word) < 3 || word[0] < 'a' || word[0] > 'z' {
+		return 0, false
+	}
+	dow, ok := dowTokens[word]
+	if !ok {
+		// e.g. "tues", "thurs"
+		for name, v := range dowTokens {
+			if len(name) > 3 && strings.HasPrefix(name, word) {
+				return v, true
+			}
+		}
+	}
+	return dow, ok
+}
+
+func naturalDayOfMonth(word string) (int, bool) {
+	if matches := naturalNthFinder.FindStringSubmatch(word); matches != nil {
+		word = matches[1]
+	}
+	dom, err := strconv.Atoi(word)
+	if err != nil || dom < domDescriptor.min || dom > domDescriptor.max {
+		return 0, false
+	}
+	return dom, true
+}
+
+func naturalMonth(word string) (int, bool) {
+	if len(word) < 3 || word[0] < 'a' || word[0] > 'z' {
+		return 0, false
+	}
+	month, ok := monthTokens[word]
+	return month, ok
+}
+
+func naturalIsThrough(word string) bool {
+	return word == "to" || word == "through" || word == "thru" || word == "until" || word == "-"
+}
+
+/******************************************************************************/
+
+// cronLine assembles the parsed clauses into a five fields cron expression.
+func (p *naturalParser) cronLine() (string, error) {
+	minute, hour := p.minute, p.hour
+
+	if len(p.times) > 0 {
+		hours, minutes := map[int]bool{}, map[int]bool{}
+		for _, t := range p.times {
+			hours[t[0]], minutes[t[1]] = true, true
+		}
+		if len(hours) > 1 && len(minutes) > 1 {
+			return "", fmt.Errorf("times in \"%s\" cannot be expressed by a single cron expression", p.text)
+		}
+		if hour != "" {
+			return "", fmt.Errorf("both an hourly frequency and times of day in \"%s\"", p.text)
+		}
+		hour = naturalJoin(toList(hours))
+		if minute == "" {
+			minute = naturalJoin(toList(minutes))
+		}
+	}
+
+	for i := 0; i < len(p.period); i += 2 {
+		from, to := p.period[i], p.period[i+1]
+		if hour != "" && hour != "*" && !strings.HasPrefix(hour, "*/") {
+			return "", fmt.Errorf("both times of day and a period in \"%s\"", p.text)
+		}
+		if minute == "" {
+			minute = strconv.Itoa(from[1])
+		}
+		// The same minutes fire in every hour, so that the first and last
+		// hours of the period must either fire all of them or none of them.
+		// The minute on the hour ending the period is the one exception,
+		// which is left out, as in "every 15 minutes between 9am and 5pm".
+		expr, err := Parse(minute + " * * * *")
+		if err != nil {
+			return "", err
+		}
+		minutes := expr.minuteList
+		first, last := from[0], to[0]
+		switch {
+		case minutes[0] >= from[1]:
+		case minutes[len(minutes)-1] < from[1]:
+			first++
+		default:
+			p.rejectWord(p.periodWords[i])
+		}
+		switch {
+		case minutes[len(minutes)-1] <= to[1]:
+		case minutes[0] > to[1] || to[1] == 0:
+			last--
+		default:
+			p.rejectWord(p.periodWords[i+1])
+		}
+		if len(p.unknown) > 0 {
+			return "", &NaturalLanguageError{Text: p.text, Unknown: p.unknown}
+		}
+		if last < first {
+			return "", fmt.Errorf("empty period in \"%s\"", p.text)
+		}
+		span := fmt.Sprintf("%d-%d", first, last)
+		if strings.HasPrefix(hour, "*/") {
+			span += hour[1:]
+		}
+		hour = span
+	}
+
+	if minute == "" {
+		minute = "0"
+	}
+	if hour == "" {
+		if minute == "*" || strings.HasPrefix(minute, "*/") {
+			hour = "*"
+		} else {
+			hour = "0"
+		}
+	}
+
+	dom := "*"
+	if len(p.doms) > 0 {
+		dom = strings.Join(p.doms, ",")
+	} else if p.dayStep != "" {
+		dom = p.dayStep
+	} else if len(p.dows) == 0 && (p.monthly || p.yearly) {
+		dom = "1"
+	}
+
+	month := "*"
+	if len(p.months) > 0 {
+		month = strings.Join(p.months, ",")
+	} else if p.yearly {
+		month = "1"
+	}
+
+	dow := "*"
+	if len(p.dows) > 0 {
+		dow = strings.Join(p.dows, ",")
+	} else if p.weekly && dom == "*" {
+		dow = "0"
+	}
+
+	return strings.Join([]string{minute, hour, dom, month, dow}, " "), nil
+}
+
+func naturalJoin(list []int) string {
+	sort.Ints(list)
+	return strings.Join(itoaList(list), ",")
+}
@@ -0,0 +1,123 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_natural_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+/******************************************************************************/
+
+var naturalTests = []struct {
+	text string
+	cron string
+}{
+	{"every minute", "* * * * *"},
+	{"every 15 minutes", "*/15 * * * *"},
+	{"every 15 minutes between 9am and 5pm", "*/15 9-16 * * *"},
+	{"every hour between 9am and 5pm", "0 9-17 * * *"},
+	{"every 2 hours between 8am and 6pm", "0 8-18/2 * * *"},
+	{"between 9:30am and 5pm", "30 9-16 * * *"},
+	{"between 9:30am and 5:30pm", "30 9-17 * * *"},
+	{"every 15 minutes between 9am and 5:45pm", "*/15 9-17 * * *"},
+	{"every 30 minutes between 9am and 5:30pm", "*/30 9-17 * * *"},
+	{"hourly", "0 * * * *"},
+	{"every day at noon", "0 12 * * *"},
+	{"daily at midnight", "0 0 * * *"},
+	{"every other day at 6am", "0 6 */2 * *"},
+	{"every weekday at 9:30am", "30 9 * * 1-5"},
+	{"Monday through Friday at 9:00 and 9:30", "0,30 9 * * 1-5"},
+	{"every Monday and Friday at 17:00", "0 17 * * 1,5"},
+	{"on weekends at 10am and 4pm", "0 10,16 * * 0,6"},
+	{"at 9am and 5pm", "0 9,17 * * *"},
+	{"every 59 minutes", "*/59 * * * *"},
+	{"every 31 days", "0 0 */31 * *"},
+	{"the second Tuesday of the month at 9", "0 9 * * 2#2"},
+	{"last Friday of every month at 5pm", "0 17 * * 5L"},
+	{"last day of the month at 11:59 pm", "59 23 L * *"},
+	{"last weekday of the month at 18:00", "0 18 LW * *"},
+	{"the weekday nearest the 15th at noon", "0 12 15W * *"},
+	{"every month on the 15th at 10am", "0 10 15 * *"},
+	{"on the 1st through the 7th at midnight", "0 0 1-7 * *"},
+	{"every quarter", "0 0 1 */3 *"},
+	{"every year on January 1st", "0 0 1 1 *"},
+	{"in January and July at 8pm on the 1st", "0 20 1 1,7 *"},
+	{"from March to June every Monday at 7am", "0 7 * 3-6 1"},
+}
+
+func TestNaturalToCron(t *testing.T) {
+	for _, test := range naturalTests {
+		cron, err := NaturalToCron(test.text)
+		require.NoError(t, err, test.text)
+		require.Equal(t, test.cron, cron, test.text)
+
+		expr, err := ParseNatural(test.text)
+		require.NoError(t, err, test.text)
+		require.NotNil(t, expr)
+	}
+}
+
+func TestNaturalToCron_Errors(t *testing.T) {
+	_, err := NaturalToCron("every blue moon at 9am")
+	var nlErr *NaturalLanguageError
+	require.True(t, errors.As(err, &nlErr), "%v", err)
+	require.Equal(t, []UnknownWord{{"blue", 6}, {"moon", 11}}, nlErr.Unknown)
+
+	_, err = ParseNatural("daily at 25:00")
+	require.True(t, errors.As(err, &nlErr), "%v", err)
+	require.Equal(t, []UnknownWord{{"25:00", 9}}, nlErr.Unknown)
+
+	// Steps larger than their field
+	for _, test := range []struct {
+		text    string
+		unknown UnknownWord
+	}{
+		{"every 90 minutes", UnknownWord{"90", 6}},
+		{"every 24 hours", UnknownWord{"24", 6}},
+		{"every 45 days", UnknownWord{"45", 6}},
+		{"every 13 months", UnknownWord{"13", 6}},
+	} {
+		_, err = ParseNatural(test.text)
+		require.True(t, errors.As(err, &nlErr), "%s: %v", test.text, err)
+		require.Equal(t, []UnknownWord{test.unknown}, nlErr.Unknown, test.text)
+	}
+
+	// Periods starting or ending within the minutes fired every hour
+	for _, test := range []struct {
+		text    string
+		unknown UnknownWord
+	}{
+		{"every 15 minutes between 9am and 5:30pm", UnknownWord{"5:30pm", 33}},
+		{"every 15 minutes between 9:30am and 5pm", UnknownWord{"9:30am", 25}},
+		{"every minute from 9:30 to 10:00", UnknownWord{"9:30", 18}},
+	} {
+		_, err = NaturalToCron(test.text)
+		require.True(t, errors.As(err, &nlErr), "%s: %v", test.text, err)
+		require.Equal(t, []UnknownWord{test.unknown}, nlErr.Unknown, test.text)
+	}
+
+	// Not representable by a single cron expression
+	for _, text := range []string{"at 9:30 and 17:15", "hourly at 9am", "every hour at 9am"} {
+		_, err = NaturalToCron(text)
+		require.Error(t, err, text)
+		require.False(t, errors.As(err, &nlErr), text)
+	}
+
+	_, err = NaturalToCron("")
+	require.Error(t, err)
+}
@@ -15,7 +15,6 @@ package cronexpr
 /******************************************************************************/
 
 import (
-	"sort"
 	"time"
 )
 
@@ -33,161 +32,12 @@ var dowNormalizedOffsets = [][]int{
 
 /******************************************************************************/
 
-func (expr *Expression) nextYear(t time.Time) time.Time {
-	// Find index at which item in list is greater or equal to
-	// candidate year
-	i := sort.SearchInts(expr.yearList, t.Year()+1)
-	if i == len(expr.yearList) {
-		return time.Time{}
-	}
-	// Year changed, need to recalculate actual days of month
-	expr.actualDaysOfMonthList = expr.calculateActualDaysOfMonth(expr.yearList[i], expr.monthList[0])
-	if len(expr.actualDaysOfMonthList) == 0 {
-		return expr.nextMonth(time.Date(
-			expr.yearList[i],
-			time.Month(expr.monthList[0]),
-			1,
-			expr.hourList[0],
-			expr.minuteList[0],
-			expr.secondList[0],
-			0,
-			t.Location()))
-	}
-	return time.Date(
-		expr.yearList[i],
-		time.Month(expr.monthList[0]),
-		expr.actualDaysOfMonthList[0],
-		expr.hourList[0],
-		expr.minuteList[0],
-		expr.secondList[0],
-		0,
-		t.Location())
-}
-
-/******************************************************************************/
-
-func (expr *Expression) nextMonth(t time.Time) time.Time {
-	// Find index at which item in list is greater or equal to
-	// candidate month
-	i := sort.SearchInts(expr.monthList, int(t.Month())+1)
-	if i == len(expr.monthList) {
-		return expr.nextYear(t)
-	}
-	// Month changed, need to recalculate actual days of month
-	expr.actualDaysOfMonthList = expr.calculateActualDaysOfMonth(t.Year(), expr.monthList[i])
-	if len(expr.actualDaysOfMonthList) == 0 {
-		return expr.nextMonth(time.Date(
-			t.Year(),
-			time.Month(expr.monthList[i]),
-			1,
-			expr.hourList[0],
-			expr.minuteList[0],
-			expr.secondList[0],
-			0,
-			t.Location()))
-	}
-
-	return time.Date(
-		t.Year(),
-		time.Month(expr.monthList[i]),
-		expr.actualDaysOfMonthList[0],
-		expr.hourList[0],
-		expr.minuteList[0],
-		expr.secondList[0],
-		0,
-		t.Location())
-}
-
-/******************************************************************************/
-
-func (expr *Expression) nextDayOfMonth(t time.Time) time.Time {
-	// Find index at which item in list is greater or equal to
-	// candidate day of month
-	i := sort.SearchInts(expr.actualDaysOfMonthList, t.Day()+1)
-	if i == len(expr.actualDaysOfMonthList) {
-		return expr.nextMonth(t)
-	}
-
-	newTime := time.Date(
-		t.Year(),
-		t.Month(),
-		expr.actualDaysOfMonthList[i],
-		expr.hourList[0],
-		expr.minuteList[0],
-		expr.secondList[0],
-		0,
-		t.Location())
-
-	// Fix for Daylight saving transition if first hour falls in the time jump
-	if newTime.Hour() < expr.hourList[0] {
-		newTime = newTime.Add(time.Duration(expr.hourList[0]-newTime.Hour()) * time.Hour).Truncate(time.Second)
-	}
-	return newTime
-}
-
-/******************************************************************************/
-
-func (expr *Expression) nextHour(t time.Time) time.Time {
-	// Find index at which item in list is greater or equal to
-	// candidate hour
-	i := sort.SearchInts(expr.hourList, t.Hour()+1)
-	if i == len(expr.hourList) {
-		return expr.nextDayOfMonth(t)
-	}
-
-	// As the absolute hour will change, Need to get dt.
-	incHours := expr.hourList[i] - t.Hour()
-
-	residuals := time.Duration(expr.minuteList[0])*time.Minute +
-		time.Duration(expr.secondList[0])*time.Second
-
-	return t.Add(time.Duration(incHours) * time.Hour).
-		Truncate(time.Hour).
-		Add(residuals)
-
-}
-
-/******************************************************************************/
-
-func (expr *Expression) nextMinute(t time.Time) time.Time {
-	// Find index at which item in list is greater or equal to
-	// candidate minute
-	i := sort.SearchInts(expr.minuteList, t.Minute()+1)
-	if i == len(expr.minuteList) {
-		return expr.nextHour(t)
-	}
-
-	residuals := time.Duration(expr.secondList[0]) * time.Second
-	return t.Truncate(time.Hour).
-		Add(time.Duration(expr.minuteList[i]) * time.Minute).
-		Truncate(time.Minute).
-		Add(residuals)
-}
-
-/******************************************************************************/
-
-func (expr *Expression) nextSecond(t time.Time) time.Time {
-	// nextSecond() assumes all other fields are exactly matched
-	// to the cron expression
-
-	// Find index at which item in list is greater or equal to
-	// candidate second
-	i := sort.SearchInts(expr.secondList, t.Second()+1)
-	if i == len(expr.secondList) {
-		return expr.nextMinute(t)
-	}
-
-	return t.Truncate(time.Minute).
-		Add(time.Duration(expr.secondList[i]) * time.Second).
-		Truncate(time.Second)
-}
-
-/******************************************************************************/
-
-func (expr *Expression) calculateActualDaysOfMonth(year, month int) []int {
-	actualDaysOfMonthMap := make(map[int]bool)
+// actualDaysOfMonth returns the days of `month` in `year` which match the
+// day-of-month and day-of-week fields.
+func (expr *Expression) actualDaysOfMonth(year, month int) bitset {
 	firstDayOfMonth := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
 	lastDayOfMonth := firstDayOfMonth.AddDate(0, 1, -1)
+	lastDom := lastDayOfMonth.Day()
 
 	// As per crontab man page (http://linux.die.net/man/5/crontab#):
 	//  "The day of a command's execution can be specified by two
@@ -196,34 +46,29 @@ func (expr *Expression) calculateActualDaysOfMonth(year, month int) []int {
 	//  "either field matches the current time"
 
 	// If both fields are not restricted, all days of the month are a hit
+	allDays := bitset(1<<uint(lastDom+1) - 2)
 	if expr.daysOfMonthRestricted == false && expr.daysOfWeekRestricted == false {
-		return genericDefaultList[1 : lastDayOfMonth.Day()+1]
+		return allDays
 	}
 
+	var actualDaysOfMonth bitset
+
 	// day-of-month != `*`
 	if expr.daysOfMonthRestricted {
 		// Last day of month
 		if expr.lastDayOfMonth {
-			actualDaysOfMonthMap[lastDayOfMonth.Day()] = true
+			actualDaysOfMonth.add(lastDom)
 		}
 		// Last work day of month
 		if expr.lastWorkdayOfMonth {
-			actualDaysOfMonthMap[workdayOfMonth(lastDayOfMonth, lastDayOfMonth)] = true
-		}
-		// Days of month
-		for v := range expr.daysOfMonth {
-			// Ignore days beyond end of month
-			if v <= lastDayOfMonth.Day() {
-				actualDaysOfMonthMap[v] = true
-			}
+			actualDaysOfMonth.add(workdayOfMonth(lastDayOfMonth, lastDayOfMonth))
 		}
+		// Days of month, ignoring days beyond end of month
+		actualDaysOfMonth |= expr.daysOfMonth & allDays
 		// Work days of month
 		// As per Wikipedia: month boundaries are not crossed.
-		for v := range expr.workdaysOfMonth {
-			// Ignore days beyond end of month
-			if v <= lastDayOfMonth.Day() {
-				actualDaysOfMonthMap[workdayOfMonth(firstDayOfMonth.AddDate(0, 0, v-1), lastDayOfMonth)] = true
-			}
+		for v := expr.workdaysOfMonth.first(); v >= 0 && v <= lastDom; v = expr.workdaysOfMonth.next(v + 1) {
+			actualDaysOfMonth.add(workdayOfMonth(firstDayOfMonth.AddDate(0, 0, v-1), lastDayOfMonth))
 		}
 	}
 
@@ -234,37 +79,37 @@ func (expr *Expression) calculateActualDaysOfMonth(year, month int) []int {
 		// days of week
 		//  offset : (7 - day_of_week_of_1st_day_of_month)
 		//  target : 1 + (7 * week_of_month) + (offset + day_of_week) % 7
-		for v := range expr.daysOfWeek {
+		for v := expr.daysOfWeek.first(); v >= 0; v = expr.daysOfWeek.next(v + 1) {
 			w := dowNormalizedOffsets[(offset+v)%7]
-			actualDaysOfMonthMap[w[0]] = true
-			actualDaysOfMonthMap[w[1]] = true
-			actualDaysOfMonthMap[w[2]] = true
-			actualDaysOfMonthMap[w[3]] = true
-			if len(w) > 4 && w[4] <= lastDayOfMonth.Day() {
-				actualDaysOfMonthMap[w[4]] = true
+			actualDaysOfMonth.add(w[0])
+			actualDaysOfMonth.add(w[1])
+			actualDaysOfMonth.add(w[2])
+			actualDaysOfMonth.add(w[3])
+			if len(w) > 4 && w[4] <= lastDom {
+				actualDaysOfMonth.add(w[4])
 			}
 		}
 		// days of week of specific week in the month
 		//  offset : (7 - day_of_week_of_1st_day_of_month)
 		//  target : 1 + (7 * week_of_month) + (offset + day_of_week) % 7
-		for v := range expr.specificWeekDaysOfWeek {
-			v = 1 + 7*(v/7) + (offset+v)%7
-			if v <= lastDayOfMonth.Day() {
-				actualDaysOfMonthMap[v] = true
+		for v := expr.specificWeekDaysOfWeek.first(); v >= 0; v = expr.specificWeekDaysOfWeek.next(v + 1) {
+			dom := 1 + 7*(v/7) + (offset+v)%7
+			if dom <= lastDom {
+				actualDaysOfMonth.add(dom)
 			}
 		}
 		// Last days of week of the month
 		lastWeekOrigin := firstDayOfMonth.AddDate(0, 1, -7)
 		offset = 7 - int(lastWeekOrigin.Weekday())
-		for v := range expr.lastWeekDaysOfWeek {
-			v = lastWeekOrigin.Day() + (offset+v)%7
-			if v <= lastDayOfMonth.Day() {
-				actualDaysOfMonthMap[v] = true
+		for v := expr.lastWeekDaysOfWeek.first(); v >= 0; v = expr.lastWeekDaysOfWeek.next(v + 1) {
+			dom := lastWeekOrigin.Day() + (offset+v)%7
+			if dom <= lastDom {
+				actualDaysOfMonth.add(dom)
 			}
 		}
 	}
 
-	return toList(actualDaysOfMonthMap)
+	return actualDaysOfMonth
 }
 
 func workdayOfMonth(targetDom, lastDom time.Time) int {
@@ -288,11 +133,6 @@ func workdayOfMonth(targetDom, lastDom time.Time) int {
 	return dom
 }
 
-func sortContains(a []int, x int) bool {
-	i := sort.SearchInts(a, x)
-	return i < len(a) && a[i] == x
-}
-
 func timeZoneInDay(t time.Time) bool {
 	if t.Location() == time.UTC {
 		return false
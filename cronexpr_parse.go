@@ -16,10 +16,9 @@ package cronexpr
 
 import (
 	"fmt"
-	"regexp"
 	"sort"
 	"strings"
-	"sync"
+	"time"
 )
 
 /******************************************************************************/
@@ -108,105 +107,282 @@ func atoi(s string) int {
 }
 
 type fieldDescriptor struct {
-	name         string
-	min, max     int
-	defaultList  []int
-	valuePattern string
-	atoi         func(string) int
+	name        string
+	min, max    int
+	defaultList []int
+	names       map[string]int
 }
 
 var (
 	secondDescriptor = fieldDescriptor{
-		name:         "second",
-		min:          0,
-		max:          59,
-		defaultList:  genericDefaultList[0:60],
-		valuePattern: `0?[0-9]|[1-5][0-9]`,
-		atoi:         atoi,
+		name:        "second",
+		min:         0,
+		max:         59,
+		defaultList: genericDefaultList[0:60],
 	}
 	minuteDescriptor = fieldDescriptor{
-		name:         "minute",
-		min:          0,
-		max:          59,
-		defaultList:  genericDefaultList[0:60],
-		valuePattern: `0?[0-9]|[1-5][0-9]`,
-		atoi:         atoi,
+		name:        "minute",
+		min:         0,
+		max:         59,
+		defaultList: genericDefaultList[0:60],
 	}
 	hourDescriptor = fieldDescriptor{
-		name:         "hour",
-		min:          0,
-		max:          23,
-		defaultList:  genericDefaultList[0:24],
-		valuePattern: `0?[0-9]|1[0-9]|2[0-3]`,
-		atoi:         atoi,
+		name:        "hour",
+		min:         0,
+		max:         23,
+		defaultList: genericDefaultList[0:24],
 	}
 	domDescriptor = fieldDescriptor{
-		name:         "day-of-month",
-		min:          1,
-		max:          31,
-		defaultList:  genericDefaultList[1:32],
-		valuePattern: `0?[1-9]|[12][0-9]|3[01]`,
-		atoi:         atoi,
+		name:        "day-of-month",
+		min:         1,
+		max:         31,
+		defaultList: genericDefaultList[1:32],
 	}
 	monthDescriptor = fieldDescriptor{
-		name:         "month",
-		min:          1,
-		max:          12,
-		defaultList:  genericDefaultList[1:13],
-		valuePattern: `0?[1-9]|1[012]|jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec|january|february|march|april|march|april|june|july|august|september|october|november|december`,
-		atoi: func(s string) int {
-			return monthTokens[s]
-		},
+		name:        "month",
+		min:         1,
+		max:         12,
+		defaultList: genericDefaultList[1:13],
+		names:       monthTokens,
 	}
 	dowDescriptor = fieldDescriptor{
-		name:         "day-of-week",
-		min:          0,
-		max:          6,
-		defaultList:  genericDefaultList[0:7],
-		valuePattern: `0?[0-7]|sun|mon|tue|wed|thu|fri|sat|sunday|monday|tuesday|wednesday|thursday|friday|saturday`,
-		atoi: func(s string) int {
-			return dowTokens[s]
-		},
+		name:        "day-of-week",
+		min:         0,
+		max:         6,
+		defaultList: genericDefaultList[0:7],
+		names:       dowTokens,
 	}
 	yearDescriptor = fieldDescriptor{
-		name:         "year",
-		min:          1970,
-		max:          2099,
-		defaultList:  yearDefaultList[:],
-		valuePattern: `19[789][0-9]|20[0-9]{2}`,
-		atoi:         atoi,
+		name:        "year",
+		min:         1970,
+		max:         2099,
+		defaultList: yearDefaultList[:],
 	}
 )
 
 /******************************************************************************/
 
-var (
-	layoutWildcard            = `^\*$|^\?$`
-	layoutValue               = `^(%value%)$`
-	layoutRange               = `^(%value%)-(%value%)$`
-	layoutWildcardAndInterval = `^\*/(\d+)$`
-	layoutValueAndInterval    = `^(%value%)/(\d+)$`
-	layoutRangeAndInterval    = `^(%value%)-(%value%)/(\d+)$`
-	layoutLastDom             = `^l$`
-	layoutWorkdom             = `^(%value%)w$`
-	layoutLastWorkdom         = `^lw$`
-	layoutDowOfLastWeek       = `^(%value%)l$`
-	layoutDowOfSpecificWeek   = `^(%value%)#([1-5])$`
-	fieldFinder               = regexp.MustCompile(`\S+`)
-	entryFinder               = regexp.MustCompile(`[^,]+`)
-	layoutRegexp              = make(map[string]*regexp.Regexp)
-	layoutRegexpLock          sync.Mutex
-)
+// A ParseOption alters the way ParseWithOptions parses cron expressions.
+type ParseOption func(*parseOptions)
+
+type parseOptions struct {
+	monthNames map[string]int
+	dowNames   map[string]int
+	strict     bool
+	reference  time.Time
+}
+
+// Strict makes the parser reject expressions which can never fire, such as
+// `0 0 30 2 *`, as well as expressions which can no longer fire after
+// `reference`, such as `0 0 * * * 2020` in 2024. A zero `reference` only
+// rejects expressions which can never fire. The returned error wraps
+// ErrUnsatisfiable.
+func Strict(reference time.Time) ParseOption {
+	return func(opts *parseOptions) {
+		opts.strict = true
+		opts.reference = reference
+	}
+}
+
+// WithMonthNames makes the parser accept the names in `names`, such as `mär`
+// or `märz`, in addition to the English month names. Names are matched
+// case-insensitively, and each must map to a month in the range [1-12].
+func WithMonthNames(names map[string]int) ParseOption {
+	return func(opts *parseOptions) {
+		opts.monthNames = mergeNames(opts.monthNames, names)
+	}
+}
+
+// WithDayOfWeekNames makes the parser accept the names in `names`, such as
+// `lun` or `lundi`, in addition to the English day-of-week names. Names are
+// matched case-insensitively, and each must map to a day of week in the range
+// [0-7], 0 and 7 being Sunday.
+func WithDayOfWeekNames(names map[string]int) ParseOption {
+	return func(opts *parseOptions) {
+		opts.dowNames = mergeNames(opts.dowNames, names)
+	}
+}
+
+// WithLocaleNames makes the parser accept the month and day-of-week names of
+// `locale`, as well as their abbreviations if `locale` is a Catalog.
+func WithLocaleNames(locale Locale) ParseOption {
+	return func(opts *parseOptions) {
+		monthNames := make(map[string]int)
+		for month := 1; month <= 12; month++ {
+			monthNames[locale.MonthName(month)] = month
+		}
+		dowNames := make(map[string]int)
+		for dow := 0; dow <= 6; dow++ {
+			dowNames[locale.DayOfWeekName(dow)] = dow
+		}
+		if catalog, ok := locale.(*Catalog); ok {
+			for i, name := range catalog.MonthAbbreviations {
+				if name != "" {
+					monthNames[name] = i + 1
+				}
+			}
+			for i, name := range catalog.DayOfWeekAbbreviations {
+				if name != "" {
+					dowNames[name] = i
+				}
+			}
+		}
+		opts.monthNames = mergeNames(opts.monthNames, monthNames)
+		opts.dowNames = mergeNames(opts.dowNames, dowNames)
+	}
+}
+
+func mergeNames(into, names map[string]int) map[string]int {
+	if into == nil {
+		into = make(map[string]int)
+	}
+	for name, v := range names {
+		into[strings.ToLower(name)] = v
+	}
+	return into
+}
+
+// withNames returns a copy of the field descriptor which also accepts the
+// names in `names`, which must be lowercase.
+func (desc fieldDescriptor) withNames(names map[string]int) (fieldDescriptor, error) {
+	if len(names) == 0 {
+		return desc, nil
+	}
+	// A day of week may be given as 7, i.e. Sunday
+	max := desc.max
+	if desc.name == dowDescriptor.name {
+		max = 7
+	}
+	merged := make(map[string]int, len(desc.names)+len(names))
+	for name, v := range desc.names {
+		merged[name] = v
+	}
+	for name, v := range names {
+		if name == "" || strings.ContainsAny(name, " \t,-/#") || v < desc.min || v > max {
+			return desc, fmt.Errorf("invalid %s name '%s': %d", desc.name, name, v)
+		}
+		merged[name] = v % (desc.max + 1)
+	}
+	desc.names = merged
+	return desc, nil
+}
+
+// value returns the value of `token`, which must be lowercase, or false if
+// `token` is neither a name nor a number of the field. Numbers have up to two
+// digits, or exactly four for years. A day of week may be given as 7, i.e.
+// Sunday.
+func (desc fieldDescriptor) value(token string) (int, bool) {
+	if v, ok := desc.names[token]; ok {
+		return v, true
+	}
+	if len(token) == 0 || len(token) > 2 && desc.max < 1000 || len(token) != 4 && desc.max >= 1000 {
+		return 0, false
+	}
+	v := 0
+	for i := 0; i < len(token); i++ {
+		if token[i] < '0' || token[i] > '9' {
+			return 0, false
+		}
+		v = v*10 + int(token[i]-'0')
+	}
+	max := desc.max
+	if desc.name == dowDescriptor.name {
+		max = 7
+	}
+	if v < desc.min || v > max {
+		return 0, false
+	}
+	return v % (desc.max + 1), true
+}
 
 /******************************************************************************/
 
-var cronNormalizer = strings.NewReplacer(
+// findFields returns the byte offsets of the beginning and end of each
+// whitespace separated field of `s`.
+func findFields(s string) [][]int {
+	indices := make([][]int, 0, fieldCount)
+	beg := -1
+	for i := 0; i < len(s); i++ {
+		if isSpace(s[i]) {
+			if beg >= 0 {
+				indices = append(indices, []int{beg, i})
+				beg = -1
+			}
+		} else if beg < 0 {
+			beg = i
+		}
+	}
+	if beg >= 0 {
+		indices = append(indices, []int{beg, len(s)})
+	}
+	return indices
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r'
+}
+
+/******************************************************************************/
+
+// Predefined expressions, each followed by its expansion
+var predefinedExpressions = []string{
 	"@yearly", "0 0 0 1 1 * *",
 	"@annually", "0 0 0 1 1 * *",
 	"@monthly", "0 0 0 1 * * *",
 	"@weekly", "0 0 0 * * 0 *",
 	"@daily", "0 0 0 * * * *",
-	"@hourly", "0 0 * * * * *")
+	"@hourly", "0 0 * * * * *",
+}
+
+var cronNormalizer = strings.NewReplacer(predefinedExpressions...)
+
+/******************************************************************************/
+
+// Indices of the fields of an expression, optional ones included
+const (
+	secondField = iota
+	minuteField
+	hourField
+	domField
+	monthField
+	dowField
+	yearField
+	fieldCount
+)
+
+// A fieldSpan locates a field of an expression within the text it was parsed
+// from. The text of a field is taken from the normalized expression, so that
+// all the fields of a predefined expression such as `@daily` span the whole
+// predefined expression. Missing optional fields have no text and span
+// nothing, at offset -1.
+type fieldSpan struct {
+	text     string
+	beg, end int
+}
+
+func locateFields(cronLine, cron string, indices [][]int) [fieldCount]fieldSpan {
+	var fields [fieldCount]fieldSpan
+	for i := range fields {
+		fields[i] = fieldSpan{beg: -1, end: -1}
+	}
+	// The second field is only present in expressions with seven fields
+	first := secondField
+	if len(indices) < fieldCount {
+		first = minuteField
+	}
+	for i, index := range indices {
+		fields[first+i] = fieldSpan{text: cron[index[0]:index[1]], beg: index[0], end: index[1]}
+	}
+	if cron != cronLine {
+		whole := findFields(cronLine)
+		for i := range fields {
+			if fields[i].beg >= 0 {
+				fields[i].beg, fields[i].end = whole[0][0], whole[len(whole)-1][1]
+			}
+		}
+	}
+	return fields
+}
 
 /******************************************************************************/
 
@@ -234,9 +410,9 @@ func (expr *Expression) hourFieldHandler(s string) error {
 
 /******************************************************************************/
 
-func (expr *Expression) monthFieldHandler(s string) error {
+func (expr *Expression) monthFieldHandler(s string, desc fieldDescriptor) error {
 	var err error
-	expr.monthList, err = genericFieldHandler(s, monthDescriptor)
+	expr.monthList, err = genericFieldHandler(s, desc)
 	return err
 }
 
@@ -287,13 +463,13 @@ func genericFieldHandler(s string, desc fieldDescriptor) ([]int, error) {
 	return toList(values), nil
 }
 
-func (expr *Expression) dowFieldHandler(s string) error {
+func (expr *Expression) dowFieldHandler(s string, desc fieldDescriptor) error {
 	expr.daysOfWeekRestricted = true
-	expr.daysOfWeek = make(map[int]bool)
-	expr.lastWeekDaysOfWeek = make(map[int]bool)
-	expr.specificWeekDaysOfWeek = make(map[int]bool)
+	expr.daysOfWeek = 0
+	expr.lastWeekDaysOfWeek = 0
+	expr.specificWeekDaysOfWeek = 0
 
-	directives, err := genericFieldParse(s, dowDescriptor)
+	directives, err := genericFieldParse(s, desc)
 	if err != nil {
 		return err
 	}
@@ -304,24 +480,24 @@ func (expr *Expression) dowFieldHandler(s string) error {
 			sdirective := s[directive.sbeg:directive.send]
 			snormal := strings.ToLower(sdirective)
 			// `5L`
-			pairs := makeLayoutRegexp(layoutDowOfLastWeek, dowDescriptor.valuePattern).FindStringSubmatchIndex(snormal)
-			if len(pairs) > 0 {
-				populateOne(expr.lastWeekDaysOfWeek, dowDescriptor.atoi(snormal[pairs[2]:pairs[3]]))
+			if v, ok := desc.value(strings.TrimSuffix(snormal, "l")); ok && strings.HasSuffix(snormal, "l") {
+				expr.lastWeekDaysOfWeek.add(v)
 			} else {
 				// `5#3`
-				pairs := makeLayoutRegexp(layoutDowOfSpecificWeek, dowDescriptor.valuePattern).FindStringSubmatchIndex(snormal)
-				if len(pairs) > 0 {
-					populateOne(expr.specificWeekDaysOfWeek, (dowDescriptor.atoi(snormal[pairs[4]:pairs[5]])-1)*7+(dowDescriptor.atoi(snormal[pairs[2]:pairs[3]])%7))
+				dow, week, _ := strings.Cut(snormal, "#")
+				v, ok := desc.value(dow)
+				if ok && len(week) == 1 && week[0] >= '1' && week[0] <= '5' {
+					expr.specificWeekDaysOfWeek.add(int(week[0]-'1')*7 + v)
 				} else {
 					return fmt.Errorf("syntax error in day-of-week field: '%s'", sdirective)
 				}
 			}
 		case one:
-			populateOne(expr.daysOfWeek, directive.first)
+			expr.daysOfWeek.add(directive.first)
 		case span:
-			populateMany(expr.daysOfWeek, directive.first, directive.last, directive.step)
+			expr.daysOfWeek.addRange(directive.first, directive.last, directive.step)
 		case all:
-			populateMany(expr.daysOfWeek, directive.first, directive.last, directive.step)
+			expr.daysOfWeek.addRange(directive.first, directive.last, directive.step)
 			expr.daysOfWeekRestricted = false
 		}
 	}
@@ -332,8 +508,8 @@ func (expr *Expression) domFieldHandler(s string) error {
 	expr.daysOfMonthRestricted = true
 	expr.lastDayOfMonth = false
 	expr.lastWorkdayOfMonth = false
-	expr.daysOfMonth = make(map[int]bool)     // days of month map
-	expr.workdaysOfMonth = make(map[int]bool) // work days of month map
+	expr.daysOfMonth = 0     // days of month set
+	expr.workdaysOfMonth = 0 // work days of month set
 
 	directives, err := genericFieldParse(s, domDescriptor)
 	if err != nil {
@@ -346,28 +522,28 @@ func (expr *Expression) domFieldHandler(s string) error {
 			sdirective := s[directive.sbeg:directive.send]
 			snormal := strings.ToLower(sdirective)
 			// `L`
-			if makeLayoutRegexp(layoutLastDom, domDescriptor.valuePattern).MatchString(snormal) {
+			if snormal == "l" {
 				expr.lastDayOfMonth = true
 			} else {
 				// `LW`
-				if makeLayoutRegexp(layoutLastWorkdom, domDescriptor.valuePattern).MatchString(snormal) {
+				if snormal == "lw" {
 					expr.lastWorkdayOfMonth = true
 				} else {
 					// `15W`
-					pairs := makeLayoutRegexp(layoutWorkdom, domDescriptor.valuePattern).FindStringSubmatchIndex(snormal)
-					if len(pairs) > 0 {
-						populateOne(expr.workdaysOfMonth, domDescriptor.atoi(snormal[pairs[2]:pairs[3]]))
+					v, ok := domDescriptor.value(strings.TrimSuffix(snormal, "w"))
+					if ok && strings.HasSuffix(snormal, "w") {
+						expr.workdaysOfMonth.add(v)
 					} else {
 						return fmt.Errorf("syntax error in day-of-month field: '%s'", sdirective)
 					}
 				}
 			}
 		case one:
-			populateOne(expr.daysOfMonth, directive.first)
+			expr.daysOfMonth.add(directive.first)
 		case span:
-			populateMany(expr.daysOfMonth, directive.first, directive.last, directive.step)
+			expr.daysOfMonth.addRange(directive.first, directive.last, directive.step)
 		case all:
-			populateMany(expr.daysOfMonth, directive.first, directive.last, directive.step)
+			expr.daysOfMonth.addRange(directive.first, directive.last, directive.step)
 			expr.daysOfMonthRestricted = false
 		}
 	}
@@ -399,105 +575,102 @@ func toList(set map[int]bool) []int {
 
 /******************************************************************************/
 
+// genericFieldParse splits a field into its comma separated entries, and
+// parses each of them in a single pass. Entries which are not made of values,
+// ranges, steps or wildcards are returned as `none` directives, for the
+// caller to deal with.
 func genericFieldParse(s string, desc fieldDescriptor) ([]*cronDirective, error) {
-	// At least one entry must be present
-	indices := entryFinder.FindAllStringIndex(s, -1)
-	if len(indices) == 0 {
-		return nil, fmt.Errorf("%s field: missing directive", desc.name)
-	}
-
-	directives := make([]*cronDirective, 0, len(indices))
-
-	for i := range indices {
-		directive := cronDirective{
-			sbeg: indices[i][0],
-			send: indices[i][1],
-		}
-		snormal := strings.ToLower(s[indices[i][0]:indices[i][1]])
-
-		// `*`
-		if makeLayoutRegexp(layoutWildcard, desc.valuePattern).MatchString(snormal) {
-			directive.kind = all
-			directive.first = desc.min
-			directive.last = desc.max
-			directive.step = 1
-			directives = append(directives, &directive)
-			continue
+	directives := make([]*cronDirective, 0, strings.Count(s, ",")+1)
+
+	for beg := 0; beg < len(s); beg++ {
+		end := strings.IndexByte(s[beg:], ',')
+		if end < 0 {
+			end = len(s)
+		} else {
+			end += beg
 		}
-		// `5`
-		if makeLayoutRegexp(layoutValue, desc.valuePattern).MatchString(snormal) {
-			directive.kind = one
-			directive.first = desc.atoi(snormal)
-			directives = append(directives, &directive)
+		// Empty entries are ignored
+		if end == beg {
 			continue
 		}
-		// `5-20`
-		pairs := makeLayoutRegexp(layoutRange, desc.valuePattern).FindStringSubmatchIndex(snormal)
-		if len(pairs) > 0 {
-			directive.kind = span
-			directive.first = desc.atoi(snormal[pairs[2]:pairs[3]])
-			directive.last = desc.atoi(snormal[pairs[4]:pairs[5]])
-			directive.step = 1
-			directives = append(directives, &directive)
-			continue
+		directive := cronDirective{
+			sbeg: beg,
+			send: end,
 		}
-		// `*/2`
-		pairs = makeLayoutRegexp(layoutWildcardAndInterval, desc.valuePattern).FindStringSubmatchIndex(snormal)
-		if len(pairs) > 0 {
-			directive.kind = span
-			directive.first = desc.min
-			directive.last = desc.max
-			directive.step = atoi(snormal[pairs[2]:pairs[3]])
-			if directive.step < 1 || directive.step > desc.max {
-				return nil, fmt.Errorf("invalid interval %s", snormal)
-			}
-			directives = append(directives, &directive)
-			continue
+		if err := desc.parseEntry(strings.ToLower(s[beg:end]), &directive); err != nil {
+			return nil, err
 		}
-		// `5/2`
-		pairs = makeLayoutRegexp(layoutValueAndInterval, desc.valuePattern).FindStringSubmatchIndex(snormal)
-		if len(pairs) > 0 {
-			directive.kind = span
-			directive.first = desc.atoi(snormal[pairs[2]:pairs[3]])
-			directive.last = desc.max
-			directive.step = atoi(snormal[pairs[4]:pairs[5]])
-			if directive.step < 1 || directive.step > desc.max {
-				return nil, fmt.Errorf("invalid interval %s", snormal)
-			}
-			directives = append(directives, &directive)
-			continue
-		}
-		// `5-20/2`
-		pairs = makeLayoutRegexp(layoutRangeAndInterval, desc.valuePattern).FindStringSubmatchIndex(snormal)
-		if len(pairs) > 0 {
-			directive.kind = span
-			directive.first = desc.atoi(snormal[pairs[2]:pairs[3]])
-			directive.last = desc.atoi(snormal[pairs[4]:pairs[5]])
-			directive.step = atoi(snormal[pairs[6]:pairs[7]])
-			if directive.step < 1 || directive.step > desc.max {
-				return nil, fmt.Errorf("invalid interval %s", snormal)
-			}
-			directives = append(directives, &directive)
-			continue
-		}
-		// No behavior for this one, let caller deal with it
-		directive.kind = none
 		directives = append(directives, &directive)
+		beg = end
+	}
+
+	// At least one entry must be present
+	if len(directives) == 0 {
+		return nil, fmt.Errorf("%s field: missing directive", desc.name)
 	}
 	return directives, nil
 }
 
-/******************************************************************************/
+// parseEntry parses a lowercase entry of a field, which is one of `*`, `5`,
+// `5-20`, `*/2`, `5/2` or `5-20/2`. The directive is left as a `none`
+// directive if the entry is none of them.
+func (desc fieldDescriptor) parseEntry(entry string, directive *cronDirective) error {
+	// `*`
+	if entry == "*" || entry == "?" {
+		directive.kind = all
+		directive.first = desc.min
+		directive.last = desc.max
+		directive.step = 1
+		return nil
+	}
 
-func makeLayoutRegexp(layout, value string) *regexp.Regexp {
-	layoutRegexpLock.Lock()
-	defer layoutRegexpLock.Unlock()
+	values, interval, hasInterval := strings.Cut(entry, "/")
+	if hasInterval && !isDigits(interval) {
+		return nil
+	}
+	sfirst, slast, hasRange := strings.Cut(values, "-")
+	first, ok := desc.value(sfirst)
+	last := desc.max
+	switch {
+	// `5-20`, `5-20/2`
+	case hasRange:
+		var lastOk bool
+		last, lastOk = desc.value(slast)
+		ok = ok && lastOk
+	// `*/2`
+	case hasInterval && values == "*":
+		first, ok = desc.min, true
+	// `5`
+	case !hasInterval:
+		if ok {
+			directive.kind = one
+			directive.first = first
+		}
+		return nil
+	}
+	if !ok {
+		return nil
+	}
 
-	layout = strings.Replace(layout, `%value%`, value, -1)
-	re := layoutRegexp[layout]
-	if re == nil {
-		re = regexp.MustCompile(layout)
-		layoutRegexp[layout] = re
+	step := 1
+	if hasInterval {
+		step = atoi(interval)
+		if step < 1 || step > desc.max {
+			return fmt.Errorf("invalid interval %s", entry)
+		}
+	}
+	directive.kind = span
+	directive.first = first
+	directive.last = last
+	directive.step = step
+	return nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
 	}
-	return re
+	return len(s) > 0
 }
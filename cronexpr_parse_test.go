@@ -0,0 +1,221 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_parse_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+/******************************************************************************/
+
+var fieldParseDescriptors = []fieldDescriptor{
+	secondDescriptor,
+	minuteDescriptor,
+	hourDescriptor,
+	domDescriptor,
+	monthDescriptor,
+	dowDescriptor,
+	yearDescriptor,
+}
+
+var fieldParseTests = []string{
+	"*", "?", "**", "5", "05", "005", "60", "0", "59", "5-20", "20-5", "*/2", "*/0", "*/60",
+	"*/05", "*/", "5/2", "5/", "/2", "5-20/2", "5-20/", "5-/2", "-5", "5-", "1,2,,3", ",", "",
+	"L", "LW", "15W", "5L", "5#3", "5#6", "#3", "l", "w", "jan", "JAN-Mar", "january/2",
+	"jun-december/3", "junee", "sun", "Sunday-sat", "7", "07", "0-7", "mon#2", "fri#5", "fril",
+	"1970", "2099", "2100", "1969", "0199", "2000-2050/10", "2000/1970", "*/7,5,10-12",
+	"1-2-3", "1/2/3", "*-5", "?/5", "\u00e9", "5,\u00e9",
+}
+
+func checkFieldParse(t *testing.T, s string, desc fieldDescriptor) {
+	expected, expectedErr := regexpFieldParse(s, desc)
+	actual, actualErr := genericFieldParse(s, desc)
+	if expectedErr != nil || actualErr != nil {
+		require.Equal(t, fmt.Sprint(expectedErr), fmt.Sprint(actualErr), "%s field: '%s'", desc.name, s)
+		return
+	}
+	require.Equal(t, expected, actual, "%s field: '%s'", desc.name, s)
+}
+
+func TestGenericFieldParse(t *testing.T) {
+	for _, desc := range fieldParseDescriptors {
+		for _, s := range fieldParseTests {
+			checkFieldParse(t, s, desc)
+		}
+	}
+}
+
+// The lexer must yield the same directives as the regexp based parser.
+func FuzzGenericFieldParse(f *testing.F) {
+	for i, s := range fieldParseTests {
+		f.Add(s, uint8(i))
+	}
+	f.Fuzz(func(t *testing.T, s string, field uint8) {
+		checkFieldParse(t, s, fieldParseDescriptors[int(field)%len(fieldParseDescriptors)])
+	})
+}
+
+func BenchmarkParse_Parallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			_ = MustParse(benchmarkExpressions[i%benchmarkExpressionsLen])
+		}
+	})
+}
+
+/******************************************************************************/
+
+// The regexp based field parser which the lexer replaced, kept as a reference
+// to check that both yield the same directives.
+
+var regexpValuePatterns = map[string]string{
+	secondDescriptor.name: `0?[0-9]|[1-5][0-9]`,
+	minuteDescriptor.name: `0?[0-9]|[1-5][0-9]`,
+	hourDescriptor.name:   `0?[0-9]|1[0-9]|2[0-3]`,
+	domDescriptor.name:    `0?[1-9]|[12][0-9]|3[01]`,
+	monthDescriptor.name:  `0?[1-9]|1[012]|jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec|january|february|march|april|march|april|june|july|august|september|october|november|december`,
+	dowDescriptor.name:    `0?[0-7]|sun|mon|tue|wed|thu|fri|sat|sunday|monday|tuesday|wednesday|thursday|friday|saturday`,
+	yearDescriptor.name:   `19[789][0-9]|20[0-9]{2}`,
+}
+
+var (
+	regexpLayoutWildcard            = `^\*$|^\?$`
+	regexpLayoutValue               = `^(%value%)$`
+	regexpLayoutRange               = `^(%value%)-(%value%)$`
+	regexpLayoutWildcardAndInterval = `^\*/(\d+)$`
+	regexpLayoutValueAndInterval    = `^(%value%)/(\d+)$`
+	regexpLayoutRangeAndInterval    = `^(%value%)-(%value%)/(\d+)$`
+	regexpEntryFinder               = regexp.MustCompile(`[^,]+`)
+	regexpLayouts                   = make(map[string]*regexp.Regexp)
+	regexpLayoutLock                sync.Mutex
+)
+
+func regexpAtoi(desc fieldDescriptor, s string) int {
+	switch desc.name {
+	case monthDescriptor.name:
+		return monthTokens[s]
+	case dowDescriptor.name:
+		return dowTokens[s]
+	}
+	return atoi(s)
+}
+
+func regexpFieldParse(s string, desc fieldDescriptor) ([]*cronDirective, error) {
+	valuePattern := regexpValuePatterns[desc.name]
+	// At least one entry must be present
+	indices := regexpEntryFinder.FindAllStringIndex(s, -1)
+	if len(indices) == 0 {
+		return nil, fmt.Errorf("%s field: missing directive", desc.name)
+	}
+
+	directives := make([]*cronDirective, 0, len(indices))
+
+	for i := range indices {
+		directive := cronDirective{
+			sbeg: indices[i][0],
+			send: indices[i][1],
+		}
+		snormal := strings.ToLower(s[indices[i][0]:indices[i][1]])
+
+		// `*`
+		if makeRegexpLayout(regexpLayoutWildcard, valuePattern).MatchString(snormal) {
+			directive.kind = all
+			directive.first = desc.min
+			directive.last = desc.max
+			directive.step = 1
+			directives = append(directives, &directive)
+			continue
+		}
+		// `5`
+		if makeRegexpLayout(regexpLayoutValue, valuePattern).MatchString(snormal) {
+			directive.kind = one
+			directive.first = regexpAtoi(desc, snormal)
+			directives = append(directives, &directive)
+			continue
+		}
+		// `5-20`
+		pairs := makeRegexpLayout(regexpLayoutRange, valuePattern).FindStringSubmatchIndex(snormal)
+		if len(pairs) > 0 {
+			directive.kind = span
+			directive.first = regexpAtoi(desc, snormal[pairs[2]:pairs[3]])
+			directive.last = regexpAtoi(desc, snormal[pairs[4]:pairs[5]])
+			directive.step = 1
+			directives = append(directives, &directive)
+			continue
+		}
+		// `*/2`
+		pairs = makeRegexpLayout(regexpLayoutWildcardAndInterval, valuePattern).FindStringSubmatchIndex(snormal)
+		if len(pairs) > 0 {
+			directive.kind = span
+			directive.first = desc.min
+			directive.last = desc.max
+			directive.step = atoi(snormal[pairs[2]:pairs[3]])
+			if directive.step < 1 || directive.step > desc.max {
+				return nil, fmt.Errorf("invalid interval %s", snormal)
+			}
+			directives = append(directives, &directive)
+			continue
+		}
+		// `5/2`
+		pairs = makeRegexpLayout(regexpLayoutValueAndInterval, valuePattern).FindStringSubmatchIndex(snormal)
+		if len(pairs) > 0 {
+			directive.kind = span
+			directive.first = regexpAtoi(desc, snormal[pairs[2]:pairs[3]])
+			directive.last = desc.max
+			directive.step = atoi(snormal[pairs[4]:pairs[5]])
+			if directive.step < 1 || directive.step > desc.max {
+				return nil, fmt.Errorf("invalid interval %s", snormal)
+			}
+			directives = append(directives, &directive)
+			continue
+		}
+		// `5-20/2`
+		pairs = makeRegexpLayout(regexpLayoutRangeAndInterval, valuePattern).FindStringSubmatchIndex(snormal)
+		if len(pairs) > 0 {
+			directive.kind = span
+			directive.first = regexpAtoi(desc, snormal[pairs[2]:pairs[3]])
+			directive.last = regexpAtoi(desc, snormal[pairs[4]:pairs[5]])
+			directive.step = atoi(snormal[pairs[6]:pairs[7]])
+			if directive.step < 1 || directive.step > desc.max {
+				return nil, fmt.Errorf("invalid interval %s", snormal)
+			}
+			directives = append(directives, &directive)
+			continue
+		}
+		// No behavior for this one, let caller deal with it
+		directive.kind = none
+		directives = append(directives, &directive)
+	}
+	return directives, nil
+}
+
+func makeRegexpLayout(layout, value string) *regexp.Regexp {
+	regexpLayoutLock.Lock()
+	defer regexpLayoutLock.Unlock()
+
+	layout = strings.Replace(layout, `%value%`, value, -1)
+	re := regexpLayouts[layout]
+	if re == nil {
+		re = regexp.MustCompile(layout)
+		regexpLayouts[layout] = re
+	}
+	return re
+}
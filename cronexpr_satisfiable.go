@@ -0,0 +1,115 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_satisfiable.go
+ * Version: 1.0
+ * License: pick the one which suits you :
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+/******************************************************************************/
+
+// ErrUnsatisfiable is wrapped by the errors returned when parsing, in strict
+// mode, an expression which cannot fire.
+var ErrUnsatisfiable = errors.New("unsatisfiable expression")
+
+/******************************************************************************/
+
+// Satisfiable reports whether `expr` matches at least one time instant, that
+// is whether each of its fields matches some value, and whether some day of
+// its months and years matches its day-of-month and day-of-week fields. For
+// instance `0 0 30 2 *`, `0 0 * 2 1#5 2023` or `5-3 * * * *` are not
+// satisfiable.
+//
+// Satisfiability is determined when the expression is parsed, so that `Next`
+// immediately returns the zero time for an expression which is not
+// satisfiable.
+func (expr *Expression) Satisfiable() bool {
+	return expr.satisfiable
+}
+
+// SatisfiableAfter reports whether `expr` matches at least one time instant
+// following `reference`.
+func (expr *Expression) SatisfiableAfter(reference time.Time) bool {
+	return expr.satisfiable && !expr.Next(reference).IsZero()
+}
+
+/******************************************************************************/
+
+// emptyField returns the index of the first field of the expression, days
+// excepted, which matches no value, as a reversed range such as `5-3` does,
+// or -1 if there is none.
+func (expr *Expression) emptyField() int {
+	lists := [fieldCount][]int{
+		secondField: expr.secondList,
+		minuteField: expr.minuteList,
+		hourField:   expr.hourList,
+		monthField:  expr.monthList,
+		yearField:   expr.yearList,
+	}
+	for field, list := range lists {
+		if field != domField && field != dowField && len(list) == 0 {
+			return field
+		}
+	}
+	return -1
+}
+
+// matchesSomeDay reports whether some day of the months and years of the
+// expression matches its days of month and days of week.
+func (expr *Expression) matchesSomeDay() bool {
+	for _, year := range expr.yearList {
+		for _, month := range expr.monthList {
+			if expr.actualDaysOfMonth(year, month) != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkSatisfiable returns an error which explains why the expression cannot
+// fire after `reference`, if so.
+func (expr *Expression) checkSatisfiable(reference time.Time) error {
+	if field := expr.emptyField(); field >= 0 {
+		return fmt.Errorf("%w: %s '%s' matches no value", ErrUnsatisfiable, fieldNames[field], expr.fields[field].text)
+	}
+	if !expr.satisfiable {
+		days := []string{}
+		if expr.daysOfMonthRestricted {
+			days = append(days, fmt.Sprintf("day-of-month '%s'", expr.fields[domField].text))
+		}
+		if expr.daysOfWeekRestricted {
+			days = append(days, fmt.Sprintf("day-of-week '%s'", expr.fields[dowField].text))
+		}
+		when := fmt.Sprintf("month '%s'", expr.fields[monthField].text)
+		if len(expr.yearList) != len(yearDescriptor.defaultList) {
+			when += fmt.Sprintf(" of year '%s'", expr.fields[yearField].text)
+		}
+		return fmt.Errorf("%w: no day in %s matches %s", ErrUnsatisfiable, when, strings.Join(days, " and "))
+	}
+	if reference.IsZero() {
+		return nil
+	}
+	if expr.yearList[len(expr.yearList)-1] < reference.Year() {
+		return fmt.Errorf("%w: year '%s' is entirely before %d", ErrUnsatisfiable, expr.fields[yearField].text, reference.Year())
+	}
+	if expr.Next(reference).IsZero() {
+		return fmt.Errorf("%w: no time instant after %s matches", ErrUnsatisfiable, reference.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_satisfiable_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+/******************************************************************************/
+
+var satisfiableTests = []struct {
+	expr        string
+	satisfiable bool
+}{
+	{"* * * * *", true},
+	{"0 0 29 2 *", true},
+	{"0 0 30 2 *", false},
+	{"0 0 30,31 2 *", false},
+	{"0 0 31 4,6,9,11 *", false},
+	{"0 0 31 4,6,9,12 *", true},
+	{"0 0 30 2 1", true},
+	{"0 0 L 2 *", true},
+	{"0 0 30W 2 *", false},
+	{"0 0 * 2 1#5", true},
+	{"0 0 * 2 1#5 2023", false},
+	{"0 0 * 2 1#5 2016", true},
+	{"0 0 29 2 * 2023", false},
+	{"0 0 29 2 * 2023,2024", true},
+	{"5-3 * * * *", false},
+	{"0 5-3 * * *", false},
+	{"0 0 * 5-3 *", false},
+	{"0 0 5-3 * *", false},
+	{"0 0 5-3 * 1", true},
+	{"0 0 * * * 2030-2025", false},
+}
+
+func TestSatisfiable(t *testing.T) {
+	from := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for _, test := range satisfiableTests {
+		expr := MustParse(test.expr)
+		if expr.Satisfiable() != test.satisfiable {
+			t.Errorf(`Satisfiable("%s") = %v, expected %v`, test.expr, !test.satisfiable, test.satisfiable)
+		}
+		// Satisfiability must agree with Next
+		if expr.Next(from).IsZero() == test.satisfiable {
+			t.Errorf(`("%s").Next("%s") disagrees with Satisfiable()`, test.expr, from)
+		}
+		_, err := ParseWithOptions(test.expr, Strict(time.Time{}))
+		if (err == nil) != test.satisfiable {
+			t.Errorf(`ParseWithOptions("%s", Strict()) returned "%v"`, test.expr, err)
+		}
+	}
+}
+
+func TestSatisfiableAfter(t *testing.T) {
+	reference := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	require.True(t, MustParse("0 0 * * * 2024").SatisfiableAfter(reference))
+	require.False(t, MustParse("0 0 * 1,2 * 2024").SatisfiableAfter(reference))
+	require.False(t, MustParse("0 0 * * * 2020-2023").SatisfiableAfter(reference))
+	require.False(t, MustParse("0 0 30 2 *").SatisfiableAfter(reference))
+
+	_, err := ParseWithOptions("0 0 * * * 2020-2023", Strict(reference))
+	require.True(t, errors.Is(err, ErrUnsatisfiable))
+	require.EqualError(t, err, "unsatisfiable expression: year '2020-2023' is entirely before 2024")
+
+	_, err = ParseWithOptions("0 0 * 1,2 * 2024", Strict(reference))
+	require.EqualError(t, err, "unsatisfiable expression: no time instant after 2024-03-01T00:00:00Z matches")
+
+	_, err = ParseWithOptions("0 0 30 2 *", Strict(reference))
+	require.EqualError(t, err, "unsatisfiable expression: no day in month '2' matches day-of-month '30'")
+
+	_, err = ParseWithOptions("0 0 * 2 1#5 2023", Strict(time.Time{}))
+	require.EqualError(t, err, "unsatisfiable expression: no day in month '2' of year '2023' matches day-of-week '1#5'")
+
+	_, err = ParseWithOptions("5-3 * * * *", Strict(time.Time{}))
+	require.EqualError(t, err, "unsatisfiable expression: minute '5-3' matches no value")
+
+	_, err = ParseWithOptions("0 0 * * * 2020-2023", Strict(time.Time{}))
+	require.NoError(t, err)
+}
@@ -0,0 +1,158 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_simplify.go
+ * Version: 1.0
+ * License: pick the one which suits you :
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/******************************************************************************/
+
+// neverFires is the shortest form of the expressions which match no time
+// instant, since February never has 30 days.
+const neverFires = "0 0 30 2 *"
+
+/******************************************************************************/
+
+// Simplify returns a new Expression pointer, equivalent to `expr` but parsed
+// from its shortest form, as returned by Minimal.
+func Simplify(expr *Expression) *Expression {
+	return MustParse(expr.Minimal())
+}
+
+/******************************************************************************/
+
+// Minimal returns the shortest cron expression equivalent to `expr`, where
+// each field is rewritten into the shortest mix of `*`, ranges, steps and
+// lists matching the same values. For instance
+// `0,5,10,15,20,25,30,35,40,45,50,55 * * * *` becomes `*/5 * * * *`.
+//
+// The optional second and year fields are only present when they are not
+// respectively `0` and `*`. All the expressions which never fire are
+// rewritten into `0 0 30 2 *`.
+func (expr *Expression) Minimal() string {
+	if !expr.satisfiable {
+		return neverFires
+	}
+	dom, dow := expr.minimalDays()
+	month := minimalField(expr.monthList, monthDescriptor)
+	fields := []string{
+		minimalField(expr.minuteList, minuteDescriptor),
+		minimalField(expr.hourList, hourDescriptor),
+		dom,
+		month,
+		dow,
+	}
+	year := minimalField(expr.yearList, yearDescriptor)
+	if len(expr.secondList) != 1 || expr.secondList[0] != 0 {
+		fields = append([]string{minimalField(expr.secondList, secondDescriptor)}, append(fields, year)...)
+	} else if year != "*" {
+		fields = append(fields, year)
+	}
+	return strings.Join(fields, " ")
+}
+
+/******************************************************************************/
+
+// minimalDays returns the shortest day-of-month and day-of-week fields of a
+// satisfiable expression.
+func (expr *Expression) minimalDays() (string, string) {
+	// Either field matching every day makes the other one irrelevant, since
+	// days of month and days of week are OR-ed
+	if !expr.daysOfMonthRestricted && !expr.daysOfWeekRestricted ||
+		expr.daysOfMonthRestricted && expr.daysOfMonth.count() == 31 ||
+		expr.daysOfWeekRestricted && expr.daysOfWeek.count() == 7 {
+		return "*", "*"
+	}
+
+	dom, dow := "*", "*"
+	if expr.daysOfMonthRestricted {
+		entries := []string{}
+		if expr.daysOfMonth != 0 {
+			entries = append(entries, minimalField(expr.daysOfMonth.list(), domDescriptor))
+		}
+		dom = strings.Join(append(entries, expr.daysOfMonthSpecial()...), ",")
+	}
+	if expr.daysOfWeekRestricted {
+		entries := []string{}
+		if expr.daysOfWeek != 0 {
+			entries = append(entries, minimalField(expr.daysOfWeek.list(), dowDescriptor))
+		}
+		dow = strings.Join(append(entries, expr.daysOfWeekSpecial()...), ",")
+	}
+	// A restricted field without any entry matches no day, so that only the
+	// other field matters
+	if dom == "" {
+		dom = "*"
+	}
+	if dow == "" {
+		dow = "*"
+	}
+	return dom, dow
+}
+
+/******************************************************************************/
+
+// minimalField returns the shortest field matching exactly the values of the
+// sorted `list`. The list is split into runs of evenly spaced values, each of
+// which is written as a value, a range or a range with a step, and the
+// shortest split is kept. An empty list, which no field can match, yields an
+// empty string.
+func minimalField(list []int, desc fieldDescriptor) string {
+	if len(list) == 0 {
+		return ""
+	}
+	if equalInts(list, desc.defaultList) {
+		return "*"
+	}
+	// shortest[i] is the shortest field matching the values of list[i:]
+	shortest := make([]string, len(list)+1)
+	for i := len(list) - 1; i >= 0; i-- {
+		step := 0
+		for j := i + 1; j <= len(list); j++ {
+			if j-i == 2 {
+				step = list[i+1] - list[i]
+			} else if j-i > 2 && list[j-1]-list[j-2] != step {
+				break
+			}
+			candidate := minimalRun(list[i], list[j-1], step, desc)
+			if j < len(list) {
+				candidate += "," + shortest[j]
+			}
+			if shortest[i] == "" || len(candidate) < len(shortest[i]) {
+				shortest[i] = candidate
+			}
+		}
+	}
+	return shortest[0]
+}
+
+// minimalRun returns the shortest directive matching the values from `first`
+// through `last`, `step` apart.
+func minimalRun(first, last, step int, desc fieldDescriptor) string {
+	switch {
+	case first == last:
+		return strconv.Itoa(first)
+	case step == 1:
+		return fmt.Sprintf("%d-%d", first, last)
+	case last+step <= desc.max:
+		return fmt.Sprintf("%d-%d/%d", first, last, step)
+	case first == desc.min:
+		return fmt.Sprintf("*/%d", step)
+	}
+	return fmt.Sprintf("%d/%d", first, step)
+}
@@ -0,0 +1,101 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_simplify_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"testing"
+	"time"
+)
+
+/******************************************************************************/
+
+var minimalTests = []struct {
+	expr    string
+	minimal string
+}{
+	{"0,5,10,15,20,25,30,35,40,45,50,55 * * * *", "*/5 * * * *"},
+	{"0-59 0-23 1-31 1-12 0-6", "* * * * *"},
+	{"5,10,15,20,25,30,35,40,45,50,55 * * * *", "5/5 * * * *"},
+	{"0 1,2,3,4,5,9,11,13 * * *", "0 1-5,9-13/2 * * *"},
+	{"0 0,6 * * *", "0 0,6 * * *"},
+	{"0 0 1,8,15,22 * *", "0 0 1-22/7 * *"},
+	{"0 0 * jan,mar,may,jul,sep,nov *", "0 0 * */2 *"},
+	{"0 0 * * mon,tue,wed,thu,fri", "0 0 * * 1-5"},
+	{"0 0 * * 7", "0 0 * * 0"},
+	{"0 0 * * 1-6,0", "0 0 * * *"},
+	{"0 0 1-31 * 1", "0 0 * * *"},
+	{"0 0 L,15W,1,2,3 * 5L,1#2", "0 0 1-3,L,15W * 5L,1#2"},
+	{"0 0 0 * * * 2020,2024,2028,2032", "0 0 * * * 2020-2032/4"},
+	{"0 0 0 * * * 2096,2097,2098,2099", "0 0 * * * 2096-2099"},
+	{"0,20,40 0 0 * * * *", "*/20 0 0 * * * *"},
+	{"@weekly", "0 0 * * 0"},
+	{"@hourly", "0 * * * *"},
+	{"0 0 5-3 * *", "0 0 30 2 *"},
+	{"0 0 * * 5-3", "0 0 30 2 *"},
+	{"0 0 5-3 * 1", "0 0 * * 1"},
+	{"0 0 1 * 5-3", "0 0 1 * *"},
+	{"5-3 * * * *", "0 0 30 2 *"},
+	{"0 0 0 * * * 2030-2025", "0 0 30 2 *"},
+	{"15 10 31 2,4 *", "0 0 30 2 *"},
+}
+
+func TestMinimal(t *testing.T) {
+	for _, test := range minimalTests {
+		minimal := MustParse(test.expr).Minimal()
+		if minimal != test.minimal {
+			t.Errorf(`Minimal("%s") = "%s", expected "%s"`, test.expr, minimal, test.minimal)
+		}
+	}
+}
+
+// A simplified expression must match the same time instants as the original
+// one, and must not be longer than its normalized form.
+func TestSimplify_Equivalence(t *testing.T) {
+	exprs := []string{}
+	for _, test := range crontests {
+		exprs = append(exprs, test.expr)
+	}
+	for _, test := range describeTests {
+		exprs = append(exprs, test.expr)
+	}
+	for _, test := range minimalTests {
+		exprs = append(exprs, test.expr)
+	}
+
+	from := time.Date(2013, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for _, s := range exprs {
+		expr := MustParse(s)
+		simplified := Simplify(expr)
+		if !Equal(expr, simplified) {
+			t.Errorf(`Simplify("%s") = "%s", which is not equivalent`, s, expr.Minimal())
+			continue
+		}
+		expected := expr.NextN(from, 50)
+		actual := simplified.NextN(from, 50)
+		if len(expected) != len(actual) {
+			t.Errorf(`Simplify("%s").NextN() returned %d time instants, expected %d`, s, len(actual), len(expected))
+			continue
+		}
+		for i := range expected {
+			if !expected[i].Equal(actual[i]) {
+				t.Errorf(`Simplify("%s").NextN()[%d] = %s, expected %s`, s, i, actual[i], expected[i])
+				break
+			}
+		}
+		if simplified.Minimal() != expr.Minimal() {
+			t.Errorf(`Minimal("%s") is not stable: "%s" then "%s"`, s, expr.Minimal(), simplified.Minimal())
+		}
+	}
+}
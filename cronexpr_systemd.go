@@ -0,0 +1,400 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_systemd.go
+ * Version: 1.0
+ * License: pick the one which suits you :
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/******************************************************************************/
+
+// An OnCalendarError is returned when converting between a cron expression
+// and a systemd `OnCalendar=` specification which uses constructs the other
+// syntax cannot express.
+type OnCalendarError struct {
+	Text        string
+	Unsupported []string
+}
+
+func (e *OnCalendarError) Error() string {
+	return fmt.Sprintf("unsupported construct(s) in \"%s\": %s", e.Text, strings.Join(e.Unsupported, ", "))
+}
+
+/******************************************************************************/
+
+// systemdDayNames are the names of the days of the week in systemd calendar
+// specifications, indexed by their value in cron expressions.
+var systemdDayNames = [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// OnCalendar returns the systemd `OnCalendar=` specification matching the
+// same time instants as `expr`, in its normalized form, i.e.
+// `Mon..Fri *-*-* 09:30:00` for `30 9 * * 1-5`. See systemd.time(7).
+//
+// The last day of the month, `L`, becomes `~01`, but systemd has no
+// equivalent for the `W`, `LW`, `#` and `nL` entries, nor for restricting
+// both the day-of-month and day-of-week fields, which cron OR-s whereas
+// systemd AND-s them. A *OnCalendarError listing these constructs is
+// returned if `expr` uses any of them.
+func (expr *Expression) OnCalendar() (string, error) {
+	if !expr.satisfiable {
+		return "", fmt.Errorf("\"%s\" never fires", expr.expression)
+	}
+	unsupported := []string{}
+	dow, daySeparator, day := "", "-", "*"
+	// Either field matching every day makes the other one irrelevant, as in
+	// minimalDays
+	everyDay := !expr.daysOfMonthRestricted && !expr.daysOfWeekRestricted ||
+		expr.daysOfMonthRestricted && expr.daysOfMonth.count() == 31 ||
+		expr.daysOfWeekRestricted && expr.daysOfWeek.count() == 7
+	if !everyDay {
+		domSpecial, dowSpecial := expr.daysOfMonthSpecial(), expr.daysOfWeekSpecial()
+		domMatches := expr.daysOfMonthRestricted && (expr.daysOfMonth != 0 || len(domSpecial) > 0)
+		dowMatches := expr.daysOfWeekRestricted && (expr.daysOfWeek != 0 || len(dowSpecial) > 0)
+		if domMatches && dowMatches {
+			unsupported = append(unsupported, "both day-of-month and day-of-week restricted (OR-ed by cron, AND-ed by systemd)")
+		}
+		if domMatches {
+			for _, entry := range domSpecial {
+				if entry != "L" {
+					unsupported = append(unsupported, unsupportedDayEntry(entry))
+				}
+			}
+			switch {
+			case !expr.lastDayOfMonth:
+				day = onCalendarField(expr.daysOfMonth.list(), domDescriptor)
+			case expr.daysOfMonth == 0:
+				daySeparator, day = "~", "01"
+			default:
+				unsupported = append(unsupported, "'L' along with other days of the month")
+			}
+		}
+		if dowMatches {
+			for _, entry := range dowSpecial {
+				unsupported = append(unsupported, unsupportedDayEntry(entry))
+			}
+			dow = onCalendarDaysOfWeek(expr.daysOfWeek) + " "
+		}
+	}
+	if len(unsupported) > 0 {
+		return "", &OnCalendarError{Text: expr.expression, Unsupported: unsupported}
+	}
+	return fmt.Sprintf("%s%s-%s%s%s %s:%s:%s",
+		dow,
+		onCalendarField(expr.yearList, yearDescriptor),
+		onCalendarField(expr.monthList, monthDescriptor),
+		daySeparator, day,
+		onCalendarField(expr.hourList, hourDescriptor),
+		onCalendarField(expr.minuteList, minuteDescriptor),
+		onCalendarField(expr.secondList, secondDescriptor)), nil
+}
+
+// unsupportedDayEntry describes a special entry of the day-of-month or
+// day-of-week field, as returned by daysOfMonthSpecial or daysOfWeekSpecial,
+// which systemd cannot express.
+func unsupportedDayEntry(entry string) string {
+	switch {
+	case entry == "LW":
+		return "'LW' (last weekday of the month)"
+	case strings.HasSuffix(entry, "W"):
+		return fmt.Sprintf("'%s' (weekday nearest a day of the month)", entry)
+	case strings.Contains(entry, "#"):
+		return fmt.Sprintf("'%s' (nth day of the week of the month)", entry)
+	}
+	return fmt.Sprintf("'%s' (last day of the week of the month)", entry)
+}
+
+// onCalendarField returns the systemd component matching exactly the values
+// of the sorted `list`: `*` for all the values of the field, a repetition
+// such as `00/15` for evenly spaced values up to the end of the field,
+// otherwise a list of values and ranges such as `01..05`.
+func onCalendarField(list []int, desc fieldDescriptor) string {
+	if equalInts(list, desc.defaultList) {
+		return "*"
+	}
+	width := 2
+	if desc.max > 99 {
+		width = 4
+	}
+	format := func(v int) string {
+		return fmt.Sprintf("%0*d", width, v)
+	}
+	// Years have no end in systemd, so that repetitions of years would not
+	// stop at the last year of cron
+	if len(list) > 2 && desc.max <= 99 {
+		step := list[1] - list[0]
+		progression := step > 1 && list[len(list)-1]+step > desc.max
+		for i := 2; progression && i < len(list); i++ {
+			progression = list[i]-list[i-1] == step
+		}
+		if progression {
+			return fmt.Sprintf("%s/%d", format(list[0]), step)
+		}
+	}
+	return onCalendarRanges(list, format)
+}
+
+// onCalendarDaysOfWeek returns the systemd names of the days of a
+// day-of-week set, in the order of systemd, which starts the week on
+// Monday, i.e. `Mon..Fri` or `Sat,Sun`.
+func onCalendarDaysOfWeek(days bitset) string {
+	positions := []int{}
+	for i := 0; i < 7; i++ {
+		if days.has((i + 1) % 7) {
+			positions = append(positions, i)
+		}
+	}
+	return onCalendarRanges(positions, func(i int) string {
+		return systemdDayNames[(i+1)%7]
+	})
+}
+
+// onCalendarRanges formats ascending values as a list of values and ranges
+// of at least three consecutive values, i.e. `00..05,10,20,21`.
+func onCalendarRanges(values []int, format func(int) string) string {
+	entries := []string{}
+	for i := 0; i < len(values); {
+		j := i + 1
+		for j < len(values) && values[j] == values[j-1]+1 {
+			j++
+		}
+		if j-i > 2 {
+			entries = append(entries, format(values[i])+".."+format(values[j-1]))
+		} else {
+			for _, v := range values[i:j] {
+				entries = append(entries, format(v))
+			}
+		}
+		i = j
+	}
+	return strings.Join(entries, ",")
+}
+
+/******************************************************************************/
+
+// onCalendarShorthands are the special expressions of systemd, in their
+// normalized form.
+var onCalendarShorthands = map[string]string{
+	"minutely":     "*-*-* *:*:00",
+	"hourly":       "*-*-* *:00:00",
+	"daily":        "*-*-* 00:00:00",
+	"weekly":       "Mon *-*-* 00:00:00",
+	"monthly":      "*-*-01 00:00:00",
+	"quarterly":    "*-01,04,07,10-01 00:00:00",
+	"semiannually": "*-01,07-01 00:00:00",
+	"yearly":       "*-01-01 00:00:00",
+	"annually":     "*-01-01 00:00:00",
+}
+
+// ParseOnCalendar returns a new Expression pointer for a systemd
+// `OnCalendar=` specification, such as `Mon..Fri *-*-* 09:30:00`. See
+// OnCalendarToCron for the supported specifications.
+func ParseOnCalendar(spec string) (*Expression, error) {
+	cronLine, err := OnCalendarToCron(spec)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(cronLine)
+}
+
+// OnCalendarToCron translates a systemd `OnCalendar=` specification into the
+// shortest equivalent cron expression, as returned by Minimal, i.e.
+// `Mon..Fri *-*-* 09:30:00` into `30 9 * * 1-5`. See systemd.time(7).
+//
+// The specification is made of optional days of the week, an optional date
+// and an optional time, which default to every day, `*-*-*` and `00:00:00`,
+// or is one of the shorthands such as `daily`. Each component is a list of
+// values, `..` ranges and `/` repetitions, and `~01` stands for the last day
+// of the month.
+//
+// A *OnCalendarError listing the constructs which cron cannot express is
+// returned if the specification uses any of them: days counted back from the
+// end of the month other than `~01`, days of the week along with days of the
+// month, which systemd AND-s whereas cron OR-s them, fractional seconds, time
+// zones and timestamps.
+func OnCalendarToCron(spec string) (string, error) {
+	words := strings.Fields(spec)
+	if len(words) == 0 {
+		return "", fmt.Errorf("empty OnCalendar specification")
+	}
+	if shorthand, ok := onCalendarShorthands[strings.ToLower(words[0])]; ok {
+		words = append(strings.Fields(shorthand), words[1:]...)
+	}
+	if strings.HasPrefix(words[0], "@") {
+		return "", &OnCalendarError{Text: spec, Unsupported: []string{fmt.Sprintf("'%s' (timestamp)", words[0])}}
+	}
+
+	var err error
+	unsupported := []string{}
+	year, month, day, dow := "*", "*", "*", "*"
+	hour, minute, second := "0", "0", "0"
+	if isOnCalendarDaysOfWeek(words[0]) {
+		if dow, err = onCalendarDaysOfWeekToCron(words[0]); err != nil {
+			return "", err
+		}
+		words = words[1:]
+	}
+	if len(words) > 0 && strings.ContainsAny(words[0], "-~") && !strings.Contains(words[0], ":") {
+		date := words[0]
+		i := strings.LastIndexAny(date, "-~")
+		rest := date[:i]
+		if j := strings.LastIndexByte(rest, '-'); j >= 0 {
+			year, rest = rest[:j], rest[j+1:]
+		}
+		if month, err = onCalendarComponentToCron(rest); err == nil {
+			year, err = onCalendarComponentToCron(year)
+		}
+		if err == nil {
+			day, err = onCalendarComponentToCron(date[i+1:])
+		}
+		if err != nil {
+			return "", fmt.Errorf("invalid date \"%s\": %s", date, err)
+		}
+		if date[i] == '~' {
+			if day == "1" {
+				day = "L"
+			} else {
+				unsupported = append(unsupported, fmt.Sprintf("'%s' (days counted back from the end of the month)", date[i:]))
+			}
+		}
+		words = words[1:]
+	}
+	if len(words) > 0 && strings.Contains(words[0], ":") {
+		parts := strings.Split(words[0], ":")
+		if len(parts) > 3 {
+			return "", fmt.Errorf("invalid time \"%s\"", words[0])
+		}
+		if len(parts) == 3 && strings.Contains(parts[2], ".") {
+			unsupported = append(unsupported, fmt.Sprintf("'%s' (fractional seconds)", parts[2]))
+			parts = parts[:2]
+		}
+		if hour, err = onCalendarComponentToCron(parts[0]); err == nil {
+			minute, err = onCalendarComponentToCron(parts[1])
+		}
+		if err == nil && len(parts) == 3 {
+			second, err = onCalendarComponentToCron(parts[2])
+		}
+		if err != nil {
+			return "", fmt.Errorf("invalid time \"%s\": %s", words[0], err)
+		}
+		words = words[1:]
+	}
+	for _, word := range words {
+		unsupported = append(unsupported, fmt.Sprintf("'%s' (time zone)", word))
+	}
+	if dow != "*" && day != "*" {
+		unsupported = append(unsupported, "both days of the week and days of the month (AND-ed by systemd, OR-ed by cron)")
+	}
+	if len(unsupported) > 0 {
+		return "", &OnCalendarError{Text: spec, Unsupported: unsupported}
+	}
+
+	expr, err := Parse(strings.Join([]string{second, minute, hour, day, month, dow, year}, " "))
+	if err != nil {
+		return "", err
+	}
+	return expr.Minimal(), nil
+}
+
+// isOnCalendarDaysOfWeek reports whether `word` is the days of the week
+// component of a specification, which is made of names of days.
+func isOnCalendarDaysOfWeek(word string) bool {
+	c := word[0] | 0x20
+	return c >= 'a' && c <= 'z'
+}
+
+// onCalendarDaysOfWeekToCron translates systemd days of the week, i.e.
+// `Mon..Fri,Sun`, into a cron day-of-week field, `*` if all the days are
+// present. Ranges follow the systemd week, from Monday to Sunday.
+func onCalendarDaysOfWeekToCron(word string) (string, error) {
+	position := func(name string) (int, error) {
+		v, ok := dowTokens[strings.ToLower(name)]
+		if !ok || name[0] >= '0' && name[0] <= '9' {
+			return 0, fmt.Errorf("invalid day of the week \"%s\"", name)
+		}
+		return (v + 6) % 7, nil
+	}
+	var days bitset
+	for _, item := range strings.Split(word, ",") {
+		first, last, found := strings.Cut(item, "..")
+		if !found {
+			first, last, found = strings.Cut(item, "-")
+		}
+		lo, err := position(first)
+		if err != nil {
+			return "", err
+		}
+		hi := lo
+		if found {
+			if hi, err = position(last); err != nil {
+				return "", err
+			}
+			if hi < lo {
+				return "", fmt.Errorf("invalid range of days of the week \"%s\"", item)
+			}
+		}
+		for i := lo; i <= hi; i++ {
+			days.add((i + 1) % 7)
+		}
+	}
+	if days.count() == 7 {
+		return "*", nil
+	}
+	values := []string{}
+	for _, v := range days.list() {
+		values = append(values, strconv.Itoa(v))
+	}
+	return strings.Join(values, ","), nil
+}
+
+// onCalendarComponentToCron translates a component of a systemd date or
+// time, i.e. `01..05,10/2`, into a cron field, `1-5,10/2`. Values are not
+// checked against the range of the field, which Parse does.
+func onCalendarComponentToCron(component string) (string, error) {
+	number := func(s string) (string, error) {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return "", fmt.Errorf("invalid value \"%s\"", s)
+		}
+		return strconv.Itoa(n), nil
+	}
+	items := strings.Split(component, ",")
+	for i, item := range items {
+		value, step, repeated := strings.Cut(item, "/")
+		if value != "*" {
+			first, last, isRange := strings.Cut(value, "..")
+			var err error
+			if value, err = number(first); err != nil {
+				return "", err
+			}
+			if isRange {
+				if last, err = number(last); err != nil {
+					return "", err
+				}
+				value += "-" + last
+			}
+		}
+		if repeated {
+			var err error
+			if step, err = number(step); err != nil {
+				return "", err
+			}
+			value += "/" + step
+		}
+		items[i] = value
+	}
+	return strings.Join(items, ","), nil
+}
@@ -0,0 +1,166 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_systemd_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+/******************************************************************************/
+
+var onCalendarTests = []struct {
+	cron       string
+	onCalendar string
+}{
+	{"30 9 * * 1-5", "Mon..Fri *-*-* 09:30:00"},
+	{"* * * * *", "*-*-* *:*:00"},
+	{"0 * * * *", "*-*-* *:00:00"},
+	{"0 0 * * *", "*-*-* 00:00:00"},
+	{"*/15 * * * *", "*-*-* *:00/15:00"},
+	{"5/20 8-18 * * *", "*-*-* 08..18:05/20:00"},
+	{"0 10,16 * * 0,6", "Sat,Sun *-*-* 10,16:00:00"},
+	{"0 8 * * 0-2,4", "Mon,Tue,Thu,Sun *-*-* 08:00:00"},
+	{"0 0 1 */3 *", "*-01/3-01 00:00:00"},
+	{"0 0 1,15 1-6 *", "*-01..06-01,15 00:00:00"},
+	{"59 23 L * *", "*-*~01 23:59:00"},
+	{"*/10 * * * * * *", "*-*-* *:*:00/10"},
+	{"0 0 12 25 12 * 2030-2032", "2030..2032-12-25 12:00:00"},
+	{"0 0 * * 1 2030", "Mon 2030-*-* 00:00:00"},
+	// Either day field matching every day leaves the other one out
+	{"0 0 1 * 0-6", "*-*-* 00:00:00"},
+	{"0 0 1-31 * 5#3", "*-*-* 00:00:00"},
+}
+
+func TestOnCalendar(t *testing.T) {
+	for _, test := range onCalendarTests {
+		expr := MustParse(test.cron)
+		onCalendar, err := expr.OnCalendar()
+		require.NoError(t, err, test.cron)
+		require.Equal(t, test.onCalendar, onCalendar, test.cron)
+
+		// The conversion round-trips
+		back, err := ParseOnCalendar(onCalendar)
+		require.NoError(t, err, onCalendar)
+		require.True(t, Equal(expr, back), "%s: %s", test.cron, back.Minimal())
+	}
+}
+
+func TestOnCalendar_Errors(t *testing.T) {
+	var onCalendarErr *OnCalendarError
+	for _, test := range []struct {
+		cron        string
+		unsupported []string
+	}{
+		{"0 12 15W * *", []string{"'15W' (weekday nearest a day of the month)"}},
+		{"0 18 LW * *", []string{"'LW' (last weekday of the month)"}},
+		{"0 9 * * 2#2", []string{"'2#2' (nth day of the week of the month)"}},
+		{"0 17 * * 5L", []string{"'5L' (last day of the week of the month)"}},
+		{"0 0 1,L * *", []string{"'L' along with other days of the month"}},
+		{"0 0 1 * 1", []string{"both day-of-month and day-of-week restricted (OR-ed by cron, AND-ed by systemd)"}},
+		{"0 0 15W * 1#1", []string{
+			"both day-of-month and day-of-week restricted (OR-ed by cron, AND-ed by systemd)",
+			"'15W' (weekday nearest a day of the month)",
+			"'1#1' (nth day of the week of the month)",
+		}},
+	} {
+		_, err := MustParse(test.cron).OnCalendar()
+		require.True(t, errors.As(err, &onCalendarErr), "%s: %v", test.cron, err)
+		require.Equal(t, test.cron, onCalendarErr.Text)
+		require.Equal(t, test.unsupported, onCalendarErr.Unsupported, test.cron)
+	}
+
+	_, err := MustParse("0 0 30 2 *").OnCalendar()
+	require.Error(t, err)
+	require.False(t, errors.As(err, &onCalendarErr))
+}
+
+/******************************************************************************/
+
+var onCalendarToCronTests = []struct {
+	onCalendar string
+	cron       string
+}{
+	{"Mon..Fri *-*-* 09:30:00", "30 9 * * 1-5"},
+	{"mon-fri 9:30", "30 9 * * 1-5"},
+	{"Sat,Sunday 10,16:00", "0 10,16 * * 0,6"},
+	{"Fri..Sun *-*-* 00:00:00", "0 0 * * 0,5,6"},
+	{"Mon..Sun 12:00", "0 12 * * *"},
+	{"Mon", "0 0 * * 1"},
+	{"*:0/15", "*/15 * * * *"},
+	{"*-*-* 08..18:05/20", "5/20 8-18 * * *"},
+	{"*-*-* *:*:*", "* * * * * * *"},
+	{"*-*-* *:*:00/10", "*/10 * * * * * *"},
+	{"2030-12-25", "0 0 25 12 * 2030"},
+	{"12-25 12:00", "0 12 25 12 *"},
+	{"*-*~01 23:59", "59 23 L * *"},
+	{"*-02~1", "0 0 L 2 *"},
+	{"minutely", "* * * * *"},
+	{"hourly", "0 * * * *"},
+	{"daily", "0 0 * * *"},
+	{"weekly", "0 0 * * 1"},
+	{"monthly", "0 0 1 * *"},
+	{"quarterly", "0 0 1 */3 *"},
+	{"semiannually", "0 0 1 1,7 *"},
+	{"yearly", "0 0 1 1 *"},
+	{"annually", "0 0 1 1 *"},
+}
+
+func TestOnCalendarToCron(t *testing.T) {
+	for _, test := range onCalendarToCronTests {
+		cron, err := OnCalendarToCron(test.onCalendar)
+		require.NoError(t, err, test.onCalendar)
+		require.Equal(t, test.cron, cron, test.onCalendar)
+
+		expr, err := ParseOnCalendar(test.onCalendar)
+		require.NoError(t, err, test.onCalendar)
+		require.NotNil(t, expr)
+	}
+
+	expr, err := ParseOnCalendar("Mon..Fri *-*-* 09:30:00")
+	require.NoError(t, err)
+	from := time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2024, time.March, 18, 9, 30, 0, 0, time.UTC), expr.Next(from))
+}
+
+func TestOnCalendarToCron_Errors(t *testing.T) {
+	var onCalendarErr *OnCalendarError
+	for _, test := range []struct {
+		onCalendar  string
+		unsupported []string
+	}{
+		{"*-*~03 12:00", []string{"'~03' (days counted back from the end of the month)"}},
+		{"Mon *-*-01..07 12:00", []string{"both days of the week and days of the month (AND-ed by systemd, OR-ed by cron)"}},
+		{"*-*-* 12:00:00.5", []string{"'00.5' (fractional seconds)"}},
+		{"daily Europe/Paris", []string{"'Europe/Paris' (time zone)"}},
+		{"*-*-* 12:00 UTC", []string{"'UTC' (time zone)"}},
+		{"@1700000000", []string{"'@1700000000' (timestamp)"}},
+	} {
+		_, err := OnCalendarToCron(test.onCalendar)
+		require.True(t, errors.As(err, &onCalendarErr), "%s: %v", test.onCalendar, err)
+		require.Equal(t, test.onCalendar, onCalendarErr.Text)
+		require.Equal(t, test.unsupported, onCalendarErr.Unsupported, test.onCalendar)
+	}
+
+	// Malformed specifications
+	for _, onCalendar := range []string{"", "Someday", "Fri..Mon", "*-*-x", "1:2:3:4", "*-13-01", "25:00"} {
+		_, err := ParseOnCalendar(onCalendar)
+		require.Error(t, err, onCalendar)
+		require.False(t, errors.As(err, &onCalendarErr), onCalendar)
+	}
+}
@@ -16,6 +16,8 @@ package cronexpr
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -1015,6 +1017,78 @@ func TestInterval_Interval60Issue(t *testing.T) {
 	}
 }
 
+func TestParseWithOptions_LocaleNames(t *testing.T) {
+	from := time.Date(2013, time.August, 31, 0, 0, 0, 0, time.UTC)
+	for _, test := range []struct {
+		expr    string
+		english string
+		options []ParseOption
+	}{
+		{"0 9 * MÄRZ Mo-Fr", "0 9 * mar mon-fri", []ParseOption{WithLocaleNames(German)}},
+		{"0 9 * mär,okt so", "0 9 * mar,oct sun", []ParseOption{WithLocaleNames(German)}},
+		{"0 9 * * lundi,mer", "0 9 * * mon,wed", []ParseOption{WithLocaleNames(French)}},
+		{"0 9 * févr-août ven#2", "0 9 * feb-aug fri#2", []ParseOption{WithLocaleNames(French)}},
+		{"0 9 * * vierne", "0 9 * * fri", []ParseOption{WithDayOfWeekNames(map[string]int{"vierne": 5})}},
+		{"0 9 * * domingo", "0 9 * * sun", []ParseOption{WithDayOfWeekNames(map[string]int{"domingo": 7})}},
+		{"0 9 * marzo *", "0 9 * mar *", []ParseOption{WithMonthNames(map[string]int{"marzo": 3})}},
+	} {
+		expr, err := ParseWithOptions(test.expr, test.options...)
+		require.NoError(t, err, test.expr)
+		require.Equal(t, MustParse(test.english).NextN(from, 10), expr.NextN(from, 10), test.expr)
+	}
+
+	// English names are still accepted, localised names are not by default
+	_, err := ParseWithOptions("0 9 * mar mon-fri", WithLocaleNames(German))
+	require.NoError(t, err)
+	_, err = Parse("0 9 * märz *")
+	require.Error(t, err)
+
+	_, err = ParseWithOptions("0 9 * * *", WithMonthNames(map[string]int{"undecimber": 13}))
+	require.Error(t, err)
+}
+
+/******************************************************************************/
+
+// The time instants matching a wide range of expressions, across daylight
+// saving transitions, are recorded in a golden file so that changes to the
+// implementation of Next can be checked to yield identical results.
+func TestNext_Golden(t *testing.T) {
+	exprs := []string{
+		"0 0 L * *", "0 0 LW * *", "0 0 1W * *", "0 0 31W * *", "0 30 2 * * 5L *",
+		"0 0 * * 1#5", "0 0 29 2 *", "0 0 1,15 * 1", "*/7 */5 * * *", "30 1-3 * * *",
+	}
+	for _, test := range crontests {
+		exprs = append(exprs, test.expr)
+	}
+	for _, test := range describeTests {
+		exprs = append(exprs, test.expr)
+	}
+	exprs = append(exprs, benchmarkExpressions...)
+
+	var b strings.Builder
+	for _, where := range []string{"UTC", "America/New_York", "America/Sao_Paulo", "Australia/Lord_Howe", "Europe/London"} {
+		loc, err := time.LoadLocation(where)
+		require.NoError(t, err)
+		for _, when := range []string{"2013-01-01 00:00:00", "2018-11-03 22:59:59", "2024-03-09 23:30:15", "2024-10-26 01:30:00"} {
+			from, err := time.ParseInLocation("2006-01-02 15:04:05", when, loc)
+			require.NoError(t, err)
+			for _, expr := range exprs {
+				fmt.Fprintf(&b, "%s\t%s\t%s\n", where, when, expr)
+				for _, next := range MustParse(expr).NextN(from, 8) {
+					fmt.Fprintf(&b, "\t%s\n", next.Format(time.RFC3339))
+				}
+			}
+		}
+	}
+	golden := filepath.Join("testdata", "next.golden")
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(golden, []byte(b.String()), 0644))
+	}
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), b.String())
+}
+
 /******************************************************************************/
 
 var benchmarkExpressions = []string{
@@ -1040,6 +1114,28 @@ func BenchmarkNext(b *testing.B) {
 		exprs[i] = MustParse(benchmarkExpressions[i])
 	}
 	from := time.Now()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		expr := exprs[i%benchmarkExpressionsLen]
+		next := expr.Next(from)
+		next = expr.Next(next)
+		next = expr.Next(next)
+		next = expr.Next(next)
+		next = expr.Next(next)
+	}
+}
+
+func BenchmarkNext_TimeZone(b *testing.B) {
+	exprs := make([]*Expression, benchmarkExpressionsLen)
+	for i := 0; i < benchmarkExpressionsLen; i++ {
+		exprs[i] = MustParse(benchmarkExpressions[i])
+	}
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(b, err)
+	// Daylight saving starts on 2024-03-10
+	from := time.Date(2024, time.March, 9, 22, 0, 0, 0, loc)
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		expr := exprs[i%benchmarkExpressionsLen]
@@ -1050,3 +1146,223 @@ func BenchmarkNext(b *testing.B) {
 		next = expr.Next(next)
 	}
 }
+
+func TestNext_ZeroAllocations(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	for _, from := range []time.Time{
+		time.Date(2024, time.January, 31, 23, 59, 59, 0, time.UTC),
+		time.Date(2024, time.March, 9, 22, 0, 0, 0, loc),
+	} {
+		for _, s := range benchmarkExpressions {
+			expr := MustParse(s)
+			allocs := testing.AllocsPerRun(100, func() {
+				next := expr.Next(from)
+				for i := 0; i < 10; i++ {
+					next = expr.Next(next)
+				}
+			})
+			if allocs != 0 {
+				t.Errorf(`("%s").Next("%s") allocates %v times`, s, from, allocs)
+			}
+		}
+	}
+}
+
+/******************************************************************************/
+
+// fuzzSeeds returns the expressions of all the table tests, which seed the
+// fuzz targets.
+func fuzzSeeds() []string {
+	seeds := append([]string{}, benchmarkExpressions...)
+	for _, test := range crontests {
+		seeds = append(seeds, test.expr)
+	}
+	for _, test := range describeTests {
+		seeds = append(seeds, test.expr)
+	}
+	for _, test := range minimalTests {
+		seeds = append(seeds, test.expr)
+	}
+	return append(seeds, "5-1 * * * *", "0 0 * * 1-7", "0 0 31W 2 *", "0 0 * * 0#5,6L 2099")
+}
+
+// Any well-formed expression must round-trip through its string form, as
+// returned by Minimal.
+func FuzzParse(f *testing.F) {
+	for _, seed := range fuzzSeeds() {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, cronLine string) {
+		expr, err := Parse(cronLine)
+		if err != nil {
+			return
+		}
+		minimal := expr.Minimal()
+		reparsed, err := Parse(minimal)
+		if err != nil {
+			t.Fatalf(`Parse("%s") failed for Minimal("%s"): %v`, minimal, cronLine, err)
+		}
+		if !Equal(expr, reparsed) {
+			t.Fatalf(`Minimal("%s") = "%s", which is not equivalent`, cronLine, minimal)
+		}
+		if again := reparsed.Minimal(); again != minimal {
+			t.Fatalf(`Minimal("%s") is not stable: "%s" then "%s"`, cronLine, minimal, again)
+		}
+		if expr.Satisfiable() != reparsed.Satisfiable() {
+			t.Fatalf(`Satisfiable("%s") differs from Satisfiable("%s")`, cronLine, minimal)
+		}
+		_ = Describe(expr)
+		_ = Lint(expr)
+	})
+}
+
+var fuzzLocations = []string{"UTC", "America/New_York", "Australia/Lord_Howe", "Asia/Kathmandu", "America/Sao_Paulo"}
+
+// Next must return the first time instant strictly after the input which
+// matches the expression, as found by a brute-force reference evaluator.
+func FuzzNext(f *testing.F) {
+	for i, seed := range fuzzSeeds() {
+		f.Add(seed, int64(1700000000+i*86400*37), uint8(i))
+	}
+	f.Fuzz(func(t *testing.T, cronLine string, unix int64, zone uint8) {
+		expr, err := Parse(cronLine)
+		if err != nil {
+			return
+		}
+		loc, err := time.LoadLocation(fuzzLocations[int(zone)%len(fuzzLocations)])
+		require.NoError(t, err)
+		// From 1970 to 2100, where years may match
+		const end = 4102444800
+		if unix < 0 {
+			unix = -(unix + 1)
+		}
+		fromTime := time.Unix(unix%end, int64(zone)*1e6).In(loc)
+
+		next := expr.Next(fromTime)
+		if !next.IsZero() {
+			if !next.After(fromTime) {
+				t.Fatalf(`("%s").Next("%s") = "%s", which is not after it`, cronLine, fromTime, next)
+			}
+			if !referenceMatches(expr, next) {
+				t.Fatalf(`("%s").Next("%s") = "%s", which does not match`, cronLine, fromTime, next)
+			}
+		}
+		if earlier := referenceNext(expr, fromTime, next); !earlier.IsZero() {
+			t.Fatalf(`("%s").Next("%s") = "%s", but "%s" matches earlier`, cronLine, fromTime, next, earlier)
+		}
+	})
+}
+
+/******************************************************************************/
+
+// referenceNext returns the first time instant after `fromTime` and before
+// `toTime` which matches `expr`, or the zero time if there is none. A zero
+// `toTime` stands for the end of the range of years. Each second of the
+// matching days is checked in turn.
+func referenceNext(expr *Expression, fromTime, toTime time.Time) time.Time {
+	// No second of any day can match
+	if len(expr.hourList) == 0 || len(expr.minuteList) == 0 || len(expr.secondList) == 0 {
+		return time.Time{}
+	}
+	loc := fromTime.Location()
+	if toTime.IsZero() {
+		toTime = time.Date(yearDescriptor.max+1, time.January, 1, 0, 0, 0, 0, loc)
+	}
+	year, month, dom := fromTime.Date()
+	for day := time.Date(year, month, dom, 0, 0, 0, 0, loc); day.Before(toTime); day = time.Date(year, month, dom+1, 0, 0, 0, 0, loc) {
+		year, month, dom = day.Date()
+		if !referenceMatchesDay(expr, year, month, dom) {
+			continue
+		}
+		t := day
+		if t.Before(fromTime) {
+			t = fromTime.Truncate(time.Second).Add(time.Second)
+		}
+		dayEnd := time.Date(year, month, dom+1, 0, 0, 0, 0, loc)
+		for ; t.Before(dayEnd) && t.Before(toTime); t = t.Add(time.Second) {
+			if referenceMatchesClock(expr, t) {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// referenceMatches reports whether the wall clock time of `t` matches `expr`.
+func referenceMatches(expr *Expression, t time.Time) bool {
+	year, month, dom := t.Date()
+	return referenceMatchesClock(expr, t) && referenceMatchesDay(expr, year, month, dom)
+}
+
+// referenceMatchesClock reports whether the time of day of `t` matches
+// `expr`, whatever its day.
+func referenceMatchesClock(expr *Expression, t time.Time) bool {
+	hour, minute, second := t.Clock()
+	return t.Nanosecond() == 0 &&
+		referenceContains(expr.hourList, hour) &&
+		referenceContains(expr.minuteList, minute) &&
+		referenceContains(expr.secondList, second)
+}
+
+// referenceMatchesDay reports whether the given day matches `expr`, as per
+// the rules of <https://github.com/gorhill/cronexpr#implementation>.
+func referenceMatchesDay(expr *Expression, year int, month time.Month, dom int) bool {
+	if !referenceContains(expr.yearList, year) || !referenceContains(expr.monthList, int(month)) {
+		return false
+	}
+	lastDom := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	weekday := func(dom int) time.Weekday {
+		return time.Date(year, month, dom, 0, 0, 0, 0, time.UTC).Weekday()
+	}
+	// The weekday nearest to `target` within the month
+	nearestWorkday := func(target int) int {
+		switch weekday(target) {
+		case time.Saturday:
+			if target == 1 {
+				return 3
+			}
+			return target - 1
+		case time.Sunday:
+			if target == lastDom {
+				return target - 2
+			}
+			return target + 1
+		}
+		return target
+	}
+
+	domMatches := expr.daysOfMonth.has(dom) ||
+		expr.lastDayOfMonth && dom == lastDom ||
+		expr.lastWorkdayOfMonth && dom == nearestWorkday(lastDom)
+	for target := 1; target <= lastDom; target++ {
+		if expr.workdaysOfMonth.has(target) && dom == nearestWorkday(target) {
+			domMatches = true
+		}
+	}
+
+	dow := int(weekday(dom))
+	week := (dom-1)/7 + 1
+	dowMatches := expr.daysOfWeek.has(dow) ||
+		expr.specificWeekDaysOfWeek.has((week-1)*7+dow) ||
+		expr.lastWeekDaysOfWeek.has(dow) && dom+7 > lastDom
+
+	switch {
+	case expr.daysOfMonthRestricted && expr.daysOfWeekRestricted:
+		return domMatches || dowMatches
+	case expr.daysOfMonthRestricted:
+		return domMatches
+	case expr.daysOfWeekRestricted:
+		return dowMatches
+	}
+	return true
+}
+
+func referenceContains(list []int, v int) bool {
+	for _, w := range list {
+		if w == v {
+			return true
+		}
+	}
+	return false
+}
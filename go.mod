@@ -2,10 +2,7 @@ module github.com/thought-machine/cronexpr
 
 go 1.19
 
-require (
-	github.com/gorhill/cronexpr v0.0.0-20180427100037-88b0669f7d75
-	github.com/stretchr/testify v1.8.1
-)
+require github.com/stretchr/testify v1.8.1
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect